@@ -2,6 +2,7 @@ package ast
 
 import (
 	"bytes"
+	"math/big"
 	"strings"
 
 	"github.com/henningrck/monkey-interpreter/token"
@@ -44,19 +45,27 @@ func (p *Program) String() string {
 	return out.String()
 }
 
+// LetStatement binds either a single Name or, when destructuring, the names
+// in Pattern. A const declaration is a LetStatement whose token is const.
 type LetStatement struct {
-	Token token.Token
-	Name  *Identifier
-	Value Expression
+	Token   token.Token
+	Name    *Identifier
+	Pattern Pattern
+	Value   Expression
 }
 
 func (ls *LetStatement) statementNode()       {}
 func (ls *LetStatement) TokenLiteral() string { return ls.Token.Literal }
+func (ls *LetStatement) IsConst() bool        { return ls.Token.Type == token.CONST }
 
 func (ls *LetStatement) String() string {
 	var out bytes.Buffer
 	out.WriteString(ls.TokenLiteral() + " ")
-	out.WriteString(ls.Name.String() + " = ")
+	if ls.Pattern != nil {
+		out.WriteString(ls.Pattern.String() + " = ")
+	} else {
+		out.WriteString(ls.Name.String() + " = ")
+	}
 
 	if ls.Value != nil {
 		out.WriteString(ls.Value.String())
@@ -111,15 +120,36 @@ func (i *Identifier) expressionNode()      {}
 func (i *Identifier) TokenLiteral() string { return i.Token.Literal }
 func (i *Identifier) String() string       { return i.Value }
 
+// IntegerLiteral holds its value in Value, unless the literal doesn't fit in
+// an int64. Big is set instead in that case.
 type IntegerLiteral struct {
 	Token token.Token
 	Value int64
+	Big   *big.Int
 }
 
 func (il *IntegerLiteral) expressionNode()      {}
 func (il *IntegerLiteral) TokenLiteral() string { return il.Token.Literal }
 func (il *IntegerLiteral) String() string       { return il.Token.Literal }
 
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
+}
+
+func (fl *FloatLiteral) expressionNode()      {}
+func (fl *FloatLiteral) TokenLiteral() string { return fl.Token.Literal }
+func (fl *FloatLiteral) String() string       { return fl.Token.Literal }
+
+type StringLiteral struct {
+	Token token.Token
+	Value string
+}
+
+func (sl *StringLiteral) expressionNode()      {}
+func (sl *StringLiteral) TokenLiteral() string { return sl.Token.Literal }
+func (sl *StringLiteral) String() string       { return Quote(sl.Value) }
+
 type BooleanLiteral struct {
 	Token token.Token
 	Value bool
@@ -129,9 +159,14 @@ func (bl *BooleanLiteral) expressionNode()      {}
 func (bl *BooleanLiteral) TokenLiteral() string { return bl.Token.Literal }
 func (bl *BooleanLiteral) String() string       { return bl.Token.Literal }
 
+// FunctionLiteral keeps the default values of its parameters in Defaults,
+// which is either empty or as long as Parameters, with nil entries for
+// parameters without a default. Rest collects any further arguments.
 type FunctionLiteral struct {
 	Token      token.Token
 	Parameters []*Identifier
+	Defaults   []Expression
+	Rest       *Identifier
 	Body       *BlockStatement
 }
 
@@ -140,20 +175,34 @@ func (fl *FunctionLiteral) TokenLiteral() string { return fl.Token.Literal }
 
 func (fl *FunctionLiteral) String() string {
 	var out bytes.Buffer
-	params := []string{}
-
-	for _, p := range fl.Parameters {
-		params = append(params, p.String())
-	}
-
 	out.WriteString(fl.TokenLiteral())
 	out.WriteString("(")
-	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(FormatParameters(fl.Parameters, fl.Defaults, fl.Rest))
 	out.WriteString(") ")
 	out.WriteString(fl.Body.String())
 	return out.String()
 }
 
+// FormatParameters formats a parameter list the way it is written in a
+// function literal, without the parentheses.
+func FormatParameters(params []*Identifier, defaults []Expression, rest *Identifier) string {
+	list := []string{}
+
+	for i, p := range params {
+		if i < len(defaults) && defaults[i] != nil {
+			list = append(list, p.String()+" = "+defaults[i].String())
+		} else {
+			list = append(list, p.String())
+		}
+	}
+
+	if rest != nil {
+		list = append(list, "..."+rest.String())
+	}
+
+	return strings.Join(list, ", ")
+}
+
 type PrefixExpression struct {
 	Token    token.Token
 	Operator string
@@ -215,10 +264,18 @@ func (ie *IfExpression) String() string {
 	return out.String()
 }
 
+type NamedArgument struct {
+	Name  *Identifier
+	Value Expression
+}
+
+// CallExpression passes its positional Arguments first, followed by any
+// NamedArguments, which are written as name: value.
 type CallExpression struct {
-	Token     token.Token
-	Function  Expression
-	Arguments []Expression
+	Token          token.Token
+	Function       Expression
+	Arguments      []Expression
+	NamedArguments []NamedArgument
 }
 
 func (ce *CallExpression) expressionNode()      {}
@@ -232,6 +289,10 @@ func (ce *CallExpression) String() string {
 		args = append(args, a.String())
 	}
 
+	for _, a := range ce.NamedArguments {
+		args = append(args, a.Name.String()+": "+a.Value.String())
+	}
+
 	out.WriteString(ce.Function.String())
 	out.WriteString("(")
 	out.WriteString(strings.Join(args, ", "))
@@ -256,3 +317,215 @@ func (bs *BlockStatement) String() string {
 
 	return out.String()
 }
+
+type ArrayLiteral struct {
+	Token    token.Token
+	Elements []Expression
+}
+
+func (al *ArrayLiteral) expressionNode()      {}
+func (al *ArrayLiteral) TokenLiteral() string { return al.Token.Literal }
+
+func (al *ArrayLiteral) String() string {
+	var out bytes.Buffer
+	elements := []string{}
+
+	for _, el := range al.Elements {
+		elements = append(elements, el.String())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+	return out.String()
+}
+
+type IndexExpression struct {
+	Token token.Token
+	Left  Expression
+	Index Expression
+}
+
+func (ie *IndexExpression) expressionNode()      {}
+func (ie *IndexExpression) TokenLiteral() string { return ie.Token.Literal }
+
+func (ie *IndexExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	out.WriteString(ie.Left.String())
+	out.WriteString("[")
+	out.WriteString(ie.Index.String())
+	out.WriteString("])")
+	return out.String()
+}
+
+type HashPair struct {
+	Key   Expression
+	Value Expression
+}
+
+// HashLiteral keeps its pairs in source order, so that printing a hash is
+// deterministic.
+type HashLiteral struct {
+	Token token.Token
+	Pairs []HashPair
+}
+
+func (hl *HashLiteral) expressionNode()      {}
+func (hl *HashLiteral) TokenLiteral() string { return hl.Token.Literal }
+
+func (hl *HashLiteral) String() string {
+	var out bytes.Buffer
+	pairs := []string{}
+
+	for _, pair := range hl.Pairs {
+		pairs = append(pairs, pair.Key.String()+": "+pair.Value.String())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
+// AssignExpression rebinds an identifier or stores into an index expression.
+// Operator is either "=" or one of the compound forms such as "+=".
+type AssignExpression struct {
+	Token    token.Token
+	Target   Expression
+	Operator string
+	Value    Expression
+}
+
+func (ae *AssignExpression) expressionNode()      {}
+func (ae *AssignExpression) TokenLiteral() string { return ae.Token.Literal }
+
+func (ae *AssignExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	out.WriteString(ae.Target.String() + " ")
+	out.WriteString(ae.Operator + " ")
+	out.WriteString(ae.Value.String())
+	out.WriteString(")")
+	return out.String()
+}
+
+type WhileStatement struct {
+	Token     token.Token
+	Condition Expression
+	Body      *BlockStatement
+}
+
+func (ws *WhileStatement) statementNode()       {}
+func (ws *WhileStatement) TokenLiteral() string { return ws.Token.Literal }
+
+func (ws *WhileStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString("while " + ws.Condition.String() + " ")
+	out.WriteString(ws.Body.String())
+	return out.String()
+}
+
+type ForStatement struct {
+	Token    token.Token
+	Variable *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+}
+
+func (fs *ForStatement) statementNode()       {}
+func (fs *ForStatement) TokenLiteral() string { return fs.Token.Literal }
+
+func (fs *ForStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString("for " + fs.Variable.String() + " in ")
+	out.WriteString(fs.Iterable.String() + " ")
+	out.WriteString(fs.Body.String())
+	return out.String()
+}
+
+type BreakStatement struct {
+	Token token.Token
+}
+
+func (bs *BreakStatement) statementNode()       {}
+func (bs *BreakStatement) TokenLiteral() string { return bs.Token.Literal }
+func (bs *BreakStatement) String() string       { return bs.Token.Literal + ";" }
+
+type ContinueStatement struct {
+	Token token.Token
+}
+
+func (cs *ContinueStatement) statementNode()       {}
+func (cs *ContinueStatement) TokenLiteral() string { return cs.Token.Literal }
+func (cs *ContinueStatement) String() string       { return cs.Token.Literal + ";" }
+
+type MatchArm struct {
+	Pattern Pattern
+	Body    Expression
+}
+
+type MatchExpression struct {
+	Token   token.Token
+	Subject Expression
+	Arms    []MatchArm
+}
+
+func (me *MatchExpression) expressionNode()      {}
+func (me *MatchExpression) TokenLiteral() string { return me.Token.Literal }
+
+func (me *MatchExpression) String() string {
+	var out bytes.Buffer
+	arms := []string{}
+
+	for _, arm := range me.Arms {
+		arms = append(arms, arm.Pattern.String()+" => "+arm.Body.String())
+	}
+
+	out.WriteString("match " + me.Subject.String() + " {")
+	out.WriteString(strings.Join(arms, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
+type ThrowStatement struct {
+	Token token.Token
+	Value Expression
+}
+
+func (ts *ThrowStatement) statementNode()       {}
+func (ts *ThrowStatement) TokenLiteral() string { return ts.Token.Literal }
+
+func (ts *ThrowStatement) String() string {
+	return ts.TokenLiteral() + " " + ts.Value.String() + ";"
+}
+
+// TryExpression has a Catch block, a Finally block or both. CatchParameter is
+// set whenever Catch is.
+type TryExpression struct {
+	Token          token.Token
+	Block          *BlockStatement
+	CatchParameter *Identifier
+	Catch          *BlockStatement
+	Finally        *BlockStatement
+}
+
+func (te *TryExpression) expressionNode()      {}
+func (te *TryExpression) TokenLiteral() string { return te.Token.Literal }
+
+func (te *TryExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("try " + te.Block.String())
+
+	if te.Catch != nil {
+		out.WriteString(" catch " + te.CatchParameter.String() + " ")
+		out.WriteString(te.Catch.String())
+	}
+
+	if te.Finally != nil {
+		out.WriteString(" finally ")
+		out.WriteString(te.Finally.String())
+	}
+
+	return out.String()
+}
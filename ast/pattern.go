@@ -0,0 +1,135 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/henningrck/monkey-interpreter/token"
+)
+
+// Pattern describes the shape a value is matched against. Patterns can bind
+// parts of the matched value to names.
+type Pattern interface {
+	Node
+	patternNode()
+}
+
+// WildcardPattern matches any value without binding it.
+type WildcardPattern struct {
+	Token token.Token
+}
+
+func (wp *WildcardPattern) patternNode()         {}
+func (wp *WildcardPattern) TokenLiteral() string { return wp.Token.Literal }
+func (wp *WildcardPattern) String() string       { return wp.Token.Literal }
+
+// LiteralPattern matches values equal to a literal. Value is a number, string
+// or boolean literal, or a negated number literal.
+type LiteralPattern struct {
+	Token token.Token
+	Value Expression
+}
+
+func (lp *LiteralPattern) patternNode()         {}
+func (lp *LiteralPattern) TokenLiteral() string { return lp.Token.Literal }
+func (lp *LiteralPattern) String() string       { return lp.Value.String() }
+
+// BindingPattern matches any value and binds it to Name.
+type BindingPattern struct {
+	Token token.Token
+	Name  *Identifier
+}
+
+func (bp *BindingPattern) patternNode()         {}
+func (bp *BindingPattern) TokenLiteral() string { return bp.Token.Literal }
+func (bp *BindingPattern) String() string       { return bp.Name.String() }
+
+// ArrayPattern matches arrays whose elements match the element patterns. If
+// Rest is set, the array may be longer and Rest binds the remaining elements;
+// otherwise the lengths have to be equal.
+type ArrayPattern struct {
+	Token    token.Token
+	Elements []Pattern
+	Rest     *Identifier
+}
+
+func (ap *ArrayPattern) patternNode()         {}
+func (ap *ArrayPattern) TokenLiteral() string { return ap.Token.Literal }
+
+func (ap *ArrayPattern) String() string {
+	var out bytes.Buffer
+	elements := []string{}
+
+	for _, el := range ap.Elements {
+		elements = append(elements, el.String())
+	}
+
+	if ap.Rest != nil {
+		elements = append(elements, "..."+ap.Rest.String())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+	return out.String()
+}
+
+type HashPatternPair struct {
+	Key   Expression
+	Value Pattern
+}
+
+// HashPattern matches hashes that contain all of its keys, with values that
+// match the value patterns. Other keys of the hash are ignored.
+type HashPattern struct {
+	Token token.Token
+	Pairs []HashPatternPair
+}
+
+func (hp *HashPattern) patternNode()         {}
+func (hp *HashPattern) TokenLiteral() string { return hp.Token.Literal }
+
+func (hp *HashPattern) String() string {
+	var out bytes.Buffer
+	pairs := []string{}
+
+	for _, pair := range hp.Pairs {
+		pairs = append(pairs, pair.Key.String()+": "+pair.Value.String())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+	return out.String()
+}
+
+// PatternBindings returns the identifiers a pattern binds, from left to right.
+// A rest element named _ doesn't bind anything, like a wildcard.
+func PatternBindings(pattern Pattern) []*Identifier {
+	switch pattern := pattern.(type) {
+	case *BindingPattern:
+		return []*Identifier{pattern.Name}
+	case *ArrayPattern:
+		bindings := []*Identifier{}
+
+		for _, el := range pattern.Elements {
+			bindings = append(bindings, PatternBindings(el)...)
+		}
+
+		if pattern.Rest != nil && pattern.Rest.Value != "_" {
+			bindings = append(bindings, pattern.Rest)
+		}
+
+		return bindings
+	case *HashPattern:
+		bindings := []*Identifier{}
+
+		for _, pair := range pattern.Pairs {
+			bindings = append(bindings, PatternBindings(pair.Value)...)
+		}
+
+		return bindings
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Quote returns s as a double-quoted string literal that the lexer reads back
+// as the same value.
+func Quote(s string) string {
+	var out strings.Builder
+	out.WriteByte('"')
+
+	for _, r := range s {
+		switch r {
+		case '"':
+			out.WriteString(`\"`)
+		case '\\':
+			out.WriteString(`\\`)
+		case '\n':
+			out.WriteString(`\n`)
+		case '\t':
+			out.WriteString(`\t`)
+		default:
+			if unicode.IsPrint(r) {
+				out.WriteRune(r)
+			} else {
+				fmt.Fprintf(&out, `\u{%X}`, r)
+			}
+		}
+	}
+
+	out.WriteByte('"')
+	return out.String()
+}
@@ -0,0 +1,124 @@
+package evaluator
+
+import (
+	"strings"
+
+	"github.com/henningrck/monkey-interpreter/ast"
+	"github.com/henningrck/monkey-interpreter/object"
+)
+
+func evalAssignExpression(node *ast.AssignExpression, env *object.Environment) object.Object {
+	switch target := node.Target.(type) {
+	case *ast.Identifier:
+		return evalIdentifierAssignment(node, target, env)
+	case *ast.IndexExpression:
+		return evalIndexAssignment(node, target, env)
+	default:
+		return newError("cannot assign to %s", node.Target.String())
+	}
+}
+
+func evalIdentifierAssignment(node *ast.AssignExpression, target *ast.Identifier, env *object.Environment) object.Object {
+	current, ok := env.Get(target.Value)
+
+	if !ok {
+		return newError("assignment to undeclared identifier: %s", target.Value)
+	}
+
+	if env.IsConst(target.Value) {
+		return newError("cannot assign to constant %s", target.Value)
+	}
+
+	val := evalAssignedValue(node, current, env)
+
+	if isAbrupt(val) {
+		return val
+	}
+
+	env.Assign(target.Value, val)
+	return val
+}
+
+func evalIndexAssignment(node *ast.AssignExpression, target *ast.IndexExpression, env *object.Environment) object.Object {
+	left := Eval(target.Left, env)
+
+	if isAbrupt(left) {
+		return left
+	}
+
+	index := Eval(target.Index, env)
+
+	if isAbrupt(index) {
+		return index
+	}
+
+	var current object.Object
+
+	if node.Operator != "=" {
+		current = evalIndexExpression(left, index)
+
+		if isAbrupt(current) {
+			return current
+		}
+	}
+
+	val := evalAssignedValue(node, current, env)
+
+	if isAbrupt(val) {
+		return val
+	}
+
+	switch left := left.(type) {
+	case *object.Array:
+		return assignArrayElement(left, index, val)
+	case *object.Hash:
+		key, ok := index.(object.Hashable)
+
+		if !ok {
+			return newError("unusable as hash key: %s", typeOf(index))
+		}
+
+		left.Set(key, val)
+		return val
+	default:
+		return newError("index assignment not supported: %s", typeOf(left))
+	}
+}
+
+// evalAssignedValue evaluates the right-hand side of an assignment. For a
+// compound operator such as +=, the result is combined with current.
+func evalAssignedValue(node *ast.AssignExpression, current object.Object, env *object.Environment) object.Object {
+	val := Eval(node.Value, env)
+
+	if isAbrupt(val) || node.Operator == "=" {
+		return val
+	}
+
+	return evalInfixExpression(strings.TrimSuffix(node.Operator, "="), current, val)
+}
+
+func assignArrayElement(array *object.Array, index, val object.Object) object.Object {
+	if typeOf(index) != object.INTEGER_OBJ {
+		return newError("array index must be INTEGER, got %s", typeOf(index))
+	}
+
+	length := int64(len(array.Elements))
+	integer, ok := index.(*object.Integer)
+
+	if !ok {
+		return newError("array index out of range: %s", index.Inspect())
+	}
+
+	idx := integer.Value
+
+	if idx < 0 {
+		idx += length
+	}
+
+	if idx < 0 || idx >= length {
+		return newError("array index out of range: %d", integer.Value)
+	}
+
+	array.Elements[idx] = val
+	return val
+}
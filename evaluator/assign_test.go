@@ -0,0 +1,102 @@
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 1; x = 2; x", "2"},
+		{"let x = 1; x = x + 1", "2"},
+		{"let x = 1; let y = 2; x = y = 5; x + y", "10"},
+		{"let x = 10; x += 5; x -= 3; x *= 2; x /= 4; x", "6"},
+		{"let x = 1.5; x *= 2; x", "3.0"},
+		{`let s = "foo"; s += "bar"; s`, "foobar"},
+		{"let arr = [1, 2, 3]; arr[0] = 10; arr[-1] += 5; arr", "[10, 2, 8]"},
+		{`let h = {"a": 1}; h["a"] += 1; h["b"] = 3; h`, "{a: 2, b: 3}"},
+		{"let a = [1]; let b = a; b[0] = 2; a", "[2]"},
+		{"let m = [[1, 2], [3, 4]]; m[1][0] = 9; m", "[[1, 2], [9, 4]]"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, testEval(test.input).Inspect(), test.input)
+	}
+}
+
+func TestAssignUpdatesDefiningScope(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{
+			`let count = 0;
+			let inc = fn() { count += 1 };
+			inc(); inc(); inc();
+			count;`,
+			3,
+		},
+		{
+			`let newCounter = fn() {
+				let n = 0;
+				fn() { n = n + 1 }
+			};
+			let a = newCounter();
+			let b = newCounter();
+			a(); a(); b();
+			a();`,
+			3,
+		},
+		{
+			`let x = 1;
+			let f = fn(x) { x = 100; x };
+			f(5) + x;`,
+			101,
+		},
+		{
+			`let x = 1;
+			if (true) { x = 2 };
+			x;`,
+			2,
+		},
+	}
+
+	for _, test := range tests {
+		checkIntegerObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestAssignErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"x = 1", "assignment to undeclared identifier: x"},
+		{"x += 1", "assignment to undeclared identifier: x"},
+		{"let f = fn() { y = 1 }; f()", "assignment to undeclared identifier: y"},
+		{"len = 1", "assignment to undeclared identifier: len"},
+		{"let x = 1; x = true + 1", "type mismatch: BOOLEAN + INTEGER"},
+		{"let x = true; x += 1", "type mismatch: BOOLEAN + INTEGER"},
+		{"let x = 1; x /= 0", "division by zero"},
+		{"let arr = [1]; arr[1] = 2", "array index out of range: 1"},
+		{"let arr = [1]; arr[-2] = 2", "array index out of range: -2"},
+		{`let arr = [1]; arr["a"] = 2`, "array index must be INTEGER, got STRING"},
+		{`let h = {}; h[[1]] = 2`, "unusable as hash key: ARRAY"},
+		{`let h = {}; h["a"] += 1`, "type mismatch: NULL + INTEGER"},
+		{`let s = "abc"; s[0] = "x"`, "index assignment not supported: STRING"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+
+		if assert.True(t, ok, "no error object returned, got %T (%+v)", evaluated, evaluated) {
+			assert.Equal(t, test.expectedMessage, errObj.Message)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package evaluator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/henningrck/monkey-interpreter/object"
+)
+
+// Output is where puts writes to.
+var Output io.Writer = os.Stdout
+
+// builtins is consulted when an identifier is not bound in the environment,
+// so scripts can shadow any builtin with their own definition.
+var builtins = map[string]*object.Builtin{}
+
+func init() {
+	RegisterBuiltin("len", builtinLen)
+	RegisterBuiltin("first", builtinFirst)
+	RegisterBuiltin("last", builtinLast)
+	RegisterBuiltin("rest", builtinRest)
+	RegisterBuiltin("push", builtinPush)
+	RegisterBuiltin("puts", builtinPuts)
+}
+
+// RegisterBuiltin makes a host function available to scripts under the given
+// name, replacing any builtin previously registered under it. It is not safe
+// to call while programs are being evaluated.
+func RegisterBuiltin(name string, fn object.BuiltinFunction) {
+	builtins[name] = &object.Builtin{Fn: fn}
+}
+
+func builtinLen(args ...object.Object) object.Object {
+	if err := checkArgumentCount(args, 1); err != nil {
+		return err
+	}
+
+	switch arg := args[0].(type) {
+	case *object.String:
+		return &object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
+	case *object.Array:
+		return &object.Integer{Value: int64(len(arg.Elements))}
+	case *object.Hash:
+		return &object.Integer{Value: int64(len(arg.Pairs))}
+	default:
+		return newError("argument to `len` not supported, got %s", typeOf(arg))
+	}
+}
+
+func builtinFirst(args ...object.Object) object.Object {
+	array, err := arrayArgument("first", args)
+
+	if err != nil {
+		return err
+	}
+
+	if len(array.Elements) == 0 {
+		return object.NULL
+	}
+
+	return array.Elements[0]
+}
+
+func builtinLast(args ...object.Object) object.Object {
+	array, err := arrayArgument("last", args)
+
+	if err != nil {
+		return err
+	}
+
+	if len(array.Elements) == 0 {
+		return object.NULL
+	}
+
+	return array.Elements[len(array.Elements)-1]
+}
+
+func builtinRest(args ...object.Object) object.Object {
+	array, err := arrayArgument("rest", args)
+
+	if err != nil {
+		return err
+	}
+
+	if len(array.Elements) == 0 {
+		return object.NULL
+	}
+
+	elements := make([]object.Object, len(array.Elements)-1)
+	copy(elements, array.Elements[1:])
+	return &object.Array{Elements: elements}
+}
+
+func builtinPush(args ...object.Object) object.Object {
+	if err := checkArgumentCount(args, 2); err != nil {
+		return err
+	}
+
+	array, ok := args[0].(*object.Array)
+
+	if !ok {
+		return newError("argument to `push` must be ARRAY, got %s", typeOf(args[0]))
+	}
+
+	elements := make([]object.Object, len(array.Elements), len(array.Elements)+1)
+	copy(elements, array.Elements)
+	elements = append(elements, args[1])
+	return &object.Array{Elements: elements}
+}
+
+func builtinPuts(args ...object.Object) object.Object {
+	for _, arg := range args {
+		fmt.Fprintln(Output, arg.Inspect())
+	}
+
+	return object.NULL
+}
+
+func checkArgumentCount(args []object.Object, want int) *object.Error {
+	if len(args) != want {
+		return newError("wrong number of arguments. got=%d, want=%d", len(args), want)
+	}
+
+	return nil
+}
+
+func arrayArgument(name string, args []object.Object) (*object.Array, *object.Error) {
+	if err := checkArgumentCount(args, 1); err != nil {
+		return nil, err
+	}
+
+	array, ok := args[0].(*object.Array)
+
+	if !ok {
+		return nil, newError("argument to `%s` must be ARRAY, got %s", name, typeOf(args[0]))
+	}
+
+	return array, nil
+}
@@ -0,0 +1,87 @@
+package evaluator_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/evaluator"
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`len("")`, 0},
+		{`len("four")`, 4},
+		{`len("hello world")`, 11},
+		{`len("größe")`, 5},
+		{`len([1, 2, 3])`, 3},
+		{`len([])`, 0},
+		{`len({"a": 1, "b": 2})`, 2},
+		{`len(1)`, "argument to `len` not supported, got INTEGER"},
+		{`len("one", "two")`, "wrong number of arguments. got=2, want=1"},
+		{`first([1, 2, 3])`, 1},
+		{`first([])`, nil},
+		{`first(1)`, "argument to `first` must be ARRAY, got INTEGER"},
+		{`last([1, 2, 3])`, 3},
+		{`last([])`, nil},
+		{`last(1)`, "argument to `last` must be ARRAY, got INTEGER"},
+		{`rest([1, 2, 3])`, []int64{2, 3}},
+		{`rest([1])`, []int64{}},
+		{`rest([])`, nil},
+		{`push([], 1)`, []int64{1}},
+		{`let a = [1]; push(a, 2); a`, []int64{1}},
+		{`push(1, 1)`, "argument to `push` must be ARRAY, got INTEGER"},
+		{`push([1])`, "wrong number of arguments. got=1, want=2"},
+		{`let len = fn(x) { 42 }; len([1])`, 42},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case int:
+			checkIntegerObject(t, evaluated, int64(expected))
+		case nil:
+			checkNullObject(t, evaluated)
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+
+			if assert.True(t, ok, "object is not Error, got %T (%+v)", evaluated, evaluated) {
+				assert.Equal(t, expected, errObj.Message)
+			}
+		case []int64:
+			array, ok := evaluated.(*object.Array)
+
+			if assert.True(t, ok, "object is not Array, got %T (%+v)", evaluated, evaluated) {
+				assert.Len(t, array.Elements, len(expected))
+
+				for i, value := range expected {
+					checkIntegerObject(t, array.Elements[i], value)
+				}
+			}
+		}
+	}
+}
+
+func TestPuts(t *testing.T) {
+	var out bytes.Buffer
+	original := evaluator.Output
+	evaluator.Output = &out
+	defer func() { evaluator.Output = original }()
+
+	evaluated := testEval(`puts("hello", 1, [true])`)
+	checkNullObject(t, evaluated)
+	assert.Equal(t, "hello\n1\n[true]\n", out.String())
+}
+
+func TestRegisterBuiltin(t *testing.T) {
+	evaluator.RegisterBuiltin("double", func(args ...object.Object) object.Object {
+		return &object.Integer{Value: args[0].(*object.Integer).Value * 2}
+	})
+
+	checkIntegerObject(t, testEval(`double(21)`), 42)
+}
@@ -0,0 +1,96 @@
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultParameters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let f = fn(x, y = 10) { x + y }; f(1)", "11"},
+		{"let f = fn(x, y = 10) { x + y }; f(1, 2)", "3"},
+		{"let f = fn(x = 1, y = x * 2) { [x, y] }; f()", "[1, 2]"},
+		{"let f = fn(x = 1, y = x * 2) { [x, y] }; f(5)", "[5, 10]"},
+		{"let k = 3; let f = fn(x = k) { x }; k = 4; f()", "4"},
+		{"let f = fn(xs = []) { push(xs, 1) }; f(); f()", "[1]"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, testEval(test.input).Inspect(), test.input)
+	}
+}
+
+func TestRestParameters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let f = fn(first, ...others) { others }; f(1, 2, 3)", "[2, 3]"},
+		{"let f = fn(first, ...others) { others }; f(1)", "[]"},
+		{"let f = fn(...all) { len(all) }; f()", "0"},
+		{"let f = fn(a, b = 2, ...c) { [a, b, c] }; f(1)", "[1, 2, []]"},
+		{"let f = fn(a, b = 2, ...c) { [a, b, c] }; f(1, 5, 6, 7)", "[1, 5, [6, 7]]"},
+		{
+			`let sum = fn(...xs) {
+				let total = 0;
+				for (x in xs) { total += x }
+				total
+			};
+			sum(1, 2, 3, 4);`,
+			"10",
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, testEval(test.input).Inspect(), test.input)
+	}
+}
+
+func TestNamedArguments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let f = fn(x, y) { [x, y] }; f(y: 2, x: 1)", "[1, 2]"},
+		{"let f = fn(x, y) { [x, y] }; f(1, y: 2)", "[1, 2]"},
+		{"let f = fn(x, y = 10, z = 20) { [x, y, z] }; f(1, z: 3)", "[1, 10, 3]"},
+		{"let f = fn(x = 1, y = x + 1) { [x, y] }; f(y: 5)", "[1, 5]"},
+		{"let f = fn(a, ...rest) { [a, rest] }; f(a: 1)", "[1, []]"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, testEval(test.input).Inspect(), test.input)
+	}
+}
+
+func TestArityErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"let f = fn(x) { x }; f()", "missing argument for parameter x"},
+		{"let f = fn(x, y) { x }; f(1)", "missing argument for parameter y"},
+		{"let f = fn(x) { x }; f(1, 2)", "wrong number of arguments. got=2, want=1"},
+		{"let f = fn(x, y = 1) { x }; f(1, 2, 3)", "wrong number of arguments. got=3, want at most 2"},
+		{"let f = fn(x) { x }; f(y: 1)", "unexpected named argument y"},
+		{"let f = fn(x) { x }; f(1, x: 2)", "multiple values for parameter x"},
+		{"let f = fn(x, ...xs) { x }; f(xs: [1])", "unexpected named argument xs"},
+		{"let f = fn(x = unknown) { x }; f()", "identifier not found: unknown"},
+		{"let f = fn(x) { x }; f(x: unknown)", "identifier not found: unknown"},
+		{"len(x: [1])", "builtin functions don't accept named arguments"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+
+		if assert.True(t, ok, "no error object returned, got %T (%+v)", evaluated, evaluated) {
+			assert.Equal(t, test.expectedMessage, errObj.Message, test.input)
+		}
+	}
+}
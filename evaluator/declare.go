@@ -0,0 +1,64 @@
+package evaluator
+
+import (
+	"github.com/henningrck/monkey-interpreter/ast"
+	"github.com/henningrck/monkey-interpreter/object"
+)
+
+// evalLetStatement binds the names of a let or const statement. A constant
+// can't share its scope with another binding of the same name; a plain let
+// may still rebind a name declared with let.
+func evalLetStatement(node *ast.LetStatement, env *object.Environment) object.Object {
+	names := []*ast.Identifier{node.Name}
+
+	if node.Pattern != nil {
+		names = ast.PatternBindings(node.Pattern)
+	}
+
+	for _, name := range names {
+		if err := checkRedeclaration(name.Value, node.IsConst(), env); err != nil {
+			return err
+		}
+	}
+
+	val := Eval(node.Value, env)
+
+	if isAbrupt(val) {
+		return val
+	}
+
+	// A pattern is bound in a scratch scope first, so that a value of the
+	// wrong shape doesn't leave some of its names bound.
+	scratch := object.NewEnvironment()
+
+	if node.Pattern == nil {
+		scratch.Set(node.Name.Value, val)
+	} else if err := bindPattern(node.Pattern, val, scratch); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		bound, _ := scratch.Get(name.Value)
+
+		if node.IsConst() {
+			env.SetConst(name.Value, bound)
+		} else {
+			env.Set(name.Value, bound)
+		}
+	}
+
+	return nil
+}
+
+func checkRedeclaration(name string, constant bool, env *object.Environment) *object.Error {
+	declared, wasConstant := env.Declared(name)
+
+	switch {
+	case declared && wasConstant:
+		return newError("cannot redeclare constant %s", name)
+	case declared && constant:
+		return newError("cannot redeclare %s as a constant", name)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,78 @@
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/evaluator"
+	"github.com/henningrck/monkey-interpreter/lexer"
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/henningrck/monkey-interpreter/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstBindings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"const PI = 3; PI * 2", "6"},
+		{"const [a, ...rest] = [1, 2, 3]; [a, rest]", "[1, [2, 3]]"},
+		{`const {name} = {"name": "Ann"}; name`, "Ann"},
+		{"const arr = [1, 2]; arr[0] = 10; arr", "[10, 2]"},
+		{"const PI = 3; let f = fn() { let PI = 4; PI }; [f(), PI]", "[4, 3]"},
+		{"let n = 0; while (n < 3) { const sq = n * n; n += 1 }; n", "3"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, testEval(test.input).Inspect(), test.input)
+	}
+}
+
+// The parser already rejects these within a single program, so each test
+// evaluates its inputs one after another in the same environment, like the
+// REPL does.
+func TestConstRuntimeErrors(t *testing.T) {
+	tests := []struct {
+		inputs          []string
+		expectedMessage string
+	}{
+		{[]string{"const PI = 3;", "PI = 4;"}, "cannot assign to constant PI"},
+		{[]string{"const PI = 3;", "PI += 1;"}, "cannot assign to constant PI"},
+		{[]string{"const PI = 3;", "let f = fn() { PI = 4 }; f()"}, "cannot assign to constant PI"},
+		{[]string{"const PI = 3;", "let PI = 4;"}, "cannot redeclare constant PI"},
+		{[]string{"const PI = 3;", "const PI = 4;"}, "cannot redeclare constant PI"},
+		{[]string{"let x = 1;", "const x = 2;"}, "cannot redeclare x as a constant"},
+		{[]string{"const [a, b] = [1, 2];", "let [c, b] = [3, 4];"}, "cannot redeclare constant b"},
+		{[]string{"let f = fn() { x = 2 }; const x = 1; f()"}, "cannot assign to constant x"},
+	}
+
+	for _, test := range tests {
+		env := object.NewEnvironment()
+		var evaluated object.Object
+
+		for _, input := range test.inputs {
+			p := parser.New(lexer.New(input))
+			program := p.ParseProgram()
+			assert.Empty(t, p.Errors(), input)
+			evaluated = evaluator.Eval(program, env)
+		}
+
+		errObj, ok := evaluated.(*object.Error)
+
+		if assert.True(t, ok, "no error object returned, got %T (%+v)", evaluated, evaluated) {
+			assert.Equal(t, test.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestConstRedeclarationLeavesValue(t *testing.T) {
+	env := object.NewEnvironment()
+
+	for _, input := range []string{"const PI = 3;", "let PI = 4;"} {
+		evaluator.Eval(parser.New(lexer.New(input)).ParseProgram(), env)
+	}
+
+	pi, _ := env.Get("PI")
+	checkIntegerObject(t, pi, 3)
+	assert.True(t, env.IsConst("PI"))
+}
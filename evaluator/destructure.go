@@ -0,0 +1,108 @@
+package evaluator
+
+import (
+	"github.com/henningrck/monkey-interpreter/ast"
+	"github.com/henningrck/monkey-interpreter/object"
+)
+
+// bindPattern binds the names in pattern to the matching parts of value in
+// env. Unlike matchPattern, a value of the wrong shape is an error.
+func bindPattern(pattern ast.Pattern, value object.Object, env *object.Environment) *object.Error {
+	switch pattern := pattern.(type) {
+	case *ast.WildcardPattern:
+		return nil
+	case *ast.BindingPattern:
+		env.Set(pattern.Name.Value, value)
+		return nil
+	case *ast.ArrayPattern:
+		return bindArrayPattern(pattern, value, env)
+	case *ast.HashPattern:
+		return bindHashPattern(pattern, value, env)
+	default:
+		return newError("cannot bind pattern %s", pattern.String())
+	}
+}
+
+func bindArrayPattern(pattern *ast.ArrayPattern, value object.Object, env *object.Environment) *object.Error {
+	array, ok := value.(*object.Array)
+
+	if !ok {
+		return newError("cannot destructure %s as ARRAY", typeOf(value))
+	}
+
+	elements := array.Elements
+	n := len(pattern.Elements)
+
+	switch {
+	case pattern.Rest == nil && len(elements) != n:
+		return newError("cannot destructure array of length %d into %d elements", len(elements), n)
+	case len(elements) < n:
+		return newError("cannot destructure array of length %d into at least %d elements", len(elements), n)
+	}
+
+	for i, el := range pattern.Elements {
+		if err := bindPattern(el, elements[i], env); err != nil {
+			return err
+		}
+	}
+
+	bindRest(pattern, elements, env)
+	return nil
+}
+
+// bindRest binds the elements left over by the element patterns to the rest
+// element of pattern, if there is one.
+func bindRest(pattern *ast.ArrayPattern, elements []object.Object, env *object.Environment) {
+	if pattern.Rest == nil || pattern.Rest.Value == "_" {
+		return
+	}
+
+	rest := make([]object.Object, len(elements)-len(pattern.Elements))
+	copy(rest, elements[len(pattern.Elements):])
+	env.Set(pattern.Rest.Value, &object.Array{Elements: rest})
+}
+
+func bindHashPattern(pattern *ast.HashPattern, value object.Object, env *object.Environment) *object.Error {
+	hash, ok := value.(*object.Hash)
+
+	if !ok {
+		return newError("cannot destructure %s as HASH", typeOf(value))
+	}
+
+	for _, pair := range pattern.Pairs {
+		element, err := lookupPatternKey(hash, pair.Key, env)
+
+		if err != nil {
+			return err
+		}
+
+		if element == nil {
+			return newError("cannot destructure hash without key %s", pair.Key.String())
+		}
+
+		if err := bindPattern(pair.Value, element, env); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lookupPatternKey evaluates the key of a hash pattern and looks it up in
+// hash. The element is nil if hash doesn't have the key.
+func lookupPatternKey(hash *object.Hash, key ast.Expression, env *object.Environment) (object.Object, *object.Error) {
+	keyObj := Eval(key, env)
+
+	if err, ok := keyObj.(*object.Error); ok {
+		return nil, err
+	}
+
+	hashKey, ok := keyObj.(object.Hashable)
+
+	if !ok {
+		return nil, newError("unusable as hash key: %s", typeOf(keyObj))
+	}
+
+	element, _ := hash.Get(hashKey)
+	return element, nil
+}
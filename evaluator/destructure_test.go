@@ -0,0 +1,77 @@
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/evaluator"
+	"github.com/henningrck/monkey-interpreter/lexer"
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/henningrck/monkey-interpreter/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDestructuringLet(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, b] = [1, 2]; a + b", "3"},
+		{"let [a, _, c] = [1, 2, 3]; [a, c]", "[1, 3]"},
+		{"let [first, ...rest] = [1, 2, 3]; rest", "[2, 3]"},
+		{"let [first, ...rest] = [1]; rest", "[]"},
+		{"let [...all] = [1, 2]; all", "[1, 2]"},
+		{"let [a, ..._] = [1, 2, 3]; a", "1"},
+		{`let {name, age} = {"name": "Ann", "age": 42, "city": "Oslo"}; [name, age]`, "[Ann, 42]"},
+		{`let {"name": n, 1: one} = {"name": "Ann", 1: "uno"}; [n, one]`, "[Ann, uno]"},
+		{`let [{id}, [x, y]] = [{"id": 7}, [8, 9]]; id + x + y`, "24"},
+		{`let {"tags": [t, ...ts]} = {"tags": [1, 2, 3]}; ts`, "[2, 3]"},
+		{"let arr = [1, 2, 3]; let [_, ...rest] = arr; rest[0] = 20; arr", "[1, 2, 3]"},
+		{"let f = fn() { let [a, b] = [1, 2]; a * b }; f()", "2"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, testEval(test.input).Inspect(), test.input)
+	}
+}
+
+func TestDestructuringErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"let [a, b] = 5;", "cannot destructure INTEGER as ARRAY"},
+		{"let [a, b] = [1, 2, 3];", "cannot destructure array of length 3 into 2 elements"},
+		{"let [a, b] = [1];", "cannot destructure array of length 1 into 2 elements"},
+		{"let [a, b, ...c] = [1];", "cannot destructure array of length 1 into at least 2 elements"},
+		{`let {name} = [1];`, "cannot destructure ARRAY as HASH"},
+		{`let {name, age} = {"name": "Ann"};`, `cannot destructure hash without key "age"`},
+		{`let [{id}] = [{"key": 1}];`, `cannot destructure hash without key "id"`},
+		{"let [a] = unknown;", "identifier not found: unknown"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+
+		if assert.True(t, ok, "no error object returned, got %T (%+v)", evaluated, evaluated) {
+			assert.Equal(t, test.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestDestructuringErrorLeavesEnvironment(t *testing.T) {
+	env := object.NewEnvironment()
+	inputs := []string{"let x = 0;", "const [a, [b]] = [1, 2];", "let [x, {y}] = [1, 2];"}
+
+	for _, input := range inputs {
+		evaluator.Eval(parser.New(lexer.New(input)).ParseProgram(), env)
+	}
+
+	for _, name := range []string{"a", "b", "y"} {
+		_, ok := env.Get(name)
+		assert.False(t, ok, name)
+	}
+
+	x, _ := env.Get("x")
+	checkIntegerObject(t, x, 0)
+}
@@ -0,0 +1,681 @@
+package evaluator
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/henningrck/monkey-interpreter/ast"
+	"github.com/henningrck/monkey-interpreter/object"
+)
+
+func Eval(node ast.Node, env *object.Environment) object.Object {
+	switch node := node.(type) {
+	case *ast.Program:
+		return evalProgram(node, env)
+	case *ast.BlockStatement:
+		return evalBlockStatement(node, env)
+	case *ast.ExpressionStatement:
+		return Eval(node.Expression, env)
+	case *ast.LetStatement:
+		return evalLetStatement(node, env)
+	case *ast.ReturnStatement:
+		val := Eval(node.ReturnValue, env)
+
+		if isAbrupt(val) {
+			return val
+		}
+
+		return &object.ReturnValue{Value: val}
+	case *ast.ThrowStatement:
+		return evalThrowStatement(node, env)
+	case *ast.WhileStatement:
+		return evalWhileStatement(node, env)
+	case *ast.ForStatement:
+		return evalForStatement(node, env)
+	case *ast.BreakStatement:
+		return object.BREAK
+	case *ast.ContinueStatement:
+		return object.CONTINUE
+	case *ast.Identifier:
+		return evalIdentifier(node, env)
+	case *ast.IntegerLiteral:
+		return evalIntegerLiteral(node)
+	case *ast.FloatLiteral:
+		return &object.Float{Value: node.Value}
+	case *ast.StringLiteral:
+		return &object.String{Value: node.Value}
+	case *ast.BooleanLiteral:
+		return nativeBoolToBooleanObject(node.Value)
+	case *ast.FunctionLiteral:
+		return &object.Function{
+			Parameters: node.Parameters,
+			Defaults:   node.Defaults,
+			Rest:       node.Rest,
+			Body:       node.Body,
+			Env:        env,
+		}
+	case *ast.PrefixExpression:
+		right := Eval(node.Right, env)
+
+		if isAbrupt(right) {
+			return right
+		}
+
+		return evalPrefixExpression(node.Operator, right)
+	case *ast.InfixExpression:
+		left := Eval(node.Left, env)
+
+		if isAbrupt(left) {
+			return left
+		}
+
+		if node.Operator == "&&" || node.Operator == "||" {
+			return evalLogicalExpression(node.Operator, left, node.Right, env)
+		}
+
+		right := Eval(node.Right, env)
+
+		if isAbrupt(right) {
+			return right
+		}
+
+		return evalInfixExpression(node.Operator, left, right)
+	case *ast.IfExpression:
+		return evalIfExpression(node, env)
+	case *ast.CallExpression:
+		function := Eval(node.Function, env)
+
+		if isAbrupt(function) {
+			return function
+		}
+
+		args := evalExpressions(node.Arguments, env)
+
+		if len(args) == 1 && isAbrupt(args[0]) {
+			return args[0]
+		}
+
+		named, abrupt := evalNamedArguments(node.NamedArguments, env)
+
+		if abrupt != nil {
+			return abrupt
+		}
+
+		result := applyFunction(function, args, named)
+
+		if err, ok := result.(*object.Error); ok {
+			addStackFrame(err, node)
+		}
+
+		return result
+	case *ast.ArrayLiteral:
+		elements := evalExpressions(node.Elements, env)
+
+		if len(elements) == 1 && isAbrupt(elements[0]) {
+			return elements[0]
+		}
+
+		return &object.Array{Elements: elements}
+	case *ast.IndexExpression:
+		left := Eval(node.Left, env)
+
+		if isAbrupt(left) {
+			return left
+		}
+
+		index := Eval(node.Index, env)
+
+		if isAbrupt(index) {
+			return index
+		}
+
+		return evalIndexExpression(left, index)
+	case *ast.HashLiteral:
+		return evalHashLiteral(node, env)
+	case *ast.AssignExpression:
+		return evalAssignExpression(node, env)
+	case *ast.MatchExpression:
+		return evalMatchExpression(node, env)
+	case *ast.TryExpression:
+		return evalTryExpression(node, env)
+	}
+
+	return nil
+}
+
+func evalProgram(program *ast.Program, env *object.Environment) object.Object {
+	var result object.Object
+
+	for _, stmt := range program.Statements {
+		result = Eval(stmt, env)
+
+		switch result := result.(type) {
+		case *object.ReturnValue:
+			return result.Value
+		case *object.Error:
+			return result
+		}
+	}
+
+	return result
+}
+
+func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
+	var result object.Object
+
+	for _, stmt := range block.Statements {
+		result = Eval(stmt, env)
+
+		if result != nil {
+			switch result.Type() {
+			case object.RETURN_VALUE_OBJ, object.ERROR_OBJ, object.BREAK_OBJ, object.CONTINUE_OBJ:
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
+func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
+	if val, ok := env.Get(node.Value); ok {
+		return val
+	}
+
+	if builtin, ok := builtins[node.Value]; ok {
+		return builtin
+	}
+
+	return newError("identifier not found: %s", node.Value)
+}
+
+func evalPrefixExpression(operator string, right object.Object) object.Object {
+	switch operator {
+	case "!":
+		return evalBangOperatorExpression(right)
+	case "-":
+		return evalMinusPrefixOperatorExpression(right)
+	case "~":
+		return evalBitNotPrefixOperatorExpression(right)
+	default:
+		return newError("unknown operator: %s%s", operator, typeOf(right))
+	}
+}
+
+func evalBangOperatorExpression(right object.Object) object.Object {
+	switch right {
+	case object.TRUE:
+		return object.FALSE
+	case object.FALSE:
+		return object.TRUE
+	case object.NULL:
+		return object.TRUE
+	default:
+		return object.FALSE
+	}
+}
+
+func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
+	switch right := right.(type) {
+	case *object.Integer:
+		if right.Value == math.MinInt64 {
+			return newInteger(new(big.Int).Neg(toBigInt(right)))
+		}
+
+		return &object.Integer{Value: -right.Value}
+	case *object.BigInteger:
+		return newInteger(new(big.Int).Neg(right.Value))
+	case *object.Float:
+		return &object.Float{Value: -right.Value}
+	default:
+		return newError("unknown operator: -%s", typeOf(right))
+	}
+}
+
+func evalBitNotPrefixOperatorExpression(right object.Object) object.Object {
+	switch right := right.(type) {
+	case *object.Integer:
+		return &object.Integer{Value: ^right.Value}
+	case *object.BigInteger:
+		return newInteger(new(big.Int).Not(right.Value))
+	default:
+		return newError("unknown operator: ~%s", typeOf(right))
+	}
+}
+
+func evalInfixExpression(operator string, left, right object.Object) object.Object {
+	switch {
+	case left == nil || right == nil:
+		return newError("unknown operator: %s %s %s", typeOf(left), operator, typeOf(right))
+	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
+		return evalIntegerInfixExpression(operator, left, right)
+	case isNumber(left) && isNumber(right):
+		return evalFloatInfixExpression(operator, left, right)
+	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
+		return evalStringInfixExpression(operator, left, right)
+	case operator == "==":
+		return nativeBoolToBooleanObject(left == right)
+	case operator == "!=":
+		return nativeBoolToBooleanObject(left != right)
+	case left.Type() != right.Type():
+		return newError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// evalLogicalExpression only evaluates the right operand when the left one
+// doesn't already decide the result. Both operators always yield a boolean.
+func evalLogicalExpression(operator string, left object.Object, rightNode ast.Expression, env *object.Environment) object.Object {
+	if operator == "&&" && !isTruthy(left) {
+		return object.FALSE
+	}
+
+	if operator == "||" && isTruthy(left) {
+		return object.TRUE
+	}
+
+	right := Eval(rightNode, env)
+
+	if isAbrupt(right) {
+		return right
+	}
+
+	return nativeBoolToBooleanObject(isTruthy(right))
+}
+
+func evalStringInfixExpression(operator string, left, right object.Object) object.Object {
+	leftVal := left.(*object.String).Value
+	rightVal := right.(*object.String).Value
+
+	switch operator {
+	case "+":
+		return &object.String{Value: leftVal + rightVal}
+	case "==":
+		return nativeBoolToBooleanObject(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal != rightVal)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// evalFloatInfixExpression handles every arithmetic or comparison where at
+// least one operand is a float. An integer operand is promoted to a float and
+// the result of arithmetic is always a float, so 1 + 2.0 is 3.0 and 1 == 1.0
+// is true. The bitwise operators are only defined for integers.
+func evalFloatInfixExpression(operator string, left, right object.Object) object.Object {
+	leftVal := toFloat(left)
+	rightVal := toFloat(right)
+
+	switch operator {
+	case "+":
+		return &object.Float{Value: leftVal + rightVal}
+	case "-":
+		return &object.Float{Value: leftVal - rightVal}
+	case "*":
+		return &object.Float{Value: leftVal * rightVal}
+	case "/":
+		if rightVal == 0 {
+			return newError("division by zero")
+		}
+
+		return &object.Float{Value: leftVal / rightVal}
+	case "%":
+		if rightVal == 0 {
+			return newError("modulo by zero")
+		}
+
+		return &object.Float{Value: math.Mod(leftVal, rightVal)}
+	case "**":
+		return &object.Float{Value: math.Pow(leftVal, rightVal)}
+	case "<":
+		return nativeBoolToBooleanObject(leftVal < rightVal)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal > rightVal)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal <= rightVal)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal >= rightVal)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal != rightVal)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+func isNumber(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Integer, *object.BigInteger, *object.Float:
+		return true
+	default:
+		return false
+	}
+}
+
+func toFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.BigInteger:
+		value, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return value
+	case *object.Float:
+		return obj.Value
+	default:
+		return math.NaN()
+	}
+}
+
+func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
+	condition := Eval(ie.Condition, env)
+
+	if isAbrupt(condition) {
+		return condition
+	}
+
+	if isTruthy(condition) {
+		return Eval(ie.Consequence, env)
+	} else if ie.Alternative != nil {
+		return Eval(ie.Alternative, env)
+	} else {
+		return object.NULL
+	}
+}
+
+func evalIndexExpression(left, index object.Object) object.Object {
+	switch {
+	case typeOf(left) == object.ARRAY_OBJ && typeOf(index) == object.INTEGER_OBJ:
+		return evalArrayIndexExpression(left, index)
+	case typeOf(left) == object.ARRAY_OBJ:
+		return newError("array index must be INTEGER, got %s", typeOf(index))
+	case typeOf(left) == object.HASH_OBJ:
+		return evalHashIndexExpression(left, index)
+	default:
+		return newError("index operator not supported: %s", typeOf(left))
+	}
+}
+
+// evalArrayIndexExpression counts negative indices from the end of the array,
+// so -1 is the last element. Indices outside the array evaluate to null.
+func evalArrayIndexExpression(array, index object.Object) object.Object {
+	elements := array.(*object.Array).Elements
+	length := int64(len(elements))
+	integer, ok := index.(*object.Integer)
+
+	if !ok {
+		return object.NULL
+	}
+
+	idx := integer.Value
+
+	if idx < 0 {
+		idx += length
+	}
+
+	if idx < 0 || idx >= length {
+		return object.NULL
+	}
+
+	return elements[idx]
+}
+
+func evalHashIndexExpression(hash, index object.Object) object.Object {
+	key, ok := index.(object.Hashable)
+
+	if !ok {
+		return newError("unusable as hash key: %s", typeOf(index))
+	}
+
+	if value, ok := hash.(*object.Hash).Get(key); ok {
+		return value
+	}
+
+	return object.NULL
+}
+
+func evalHashLiteral(node *ast.HashLiteral, env *object.Environment) object.Object {
+	hash := object.NewHash()
+
+	for _, pair := range node.Pairs {
+		key := Eval(pair.Key, env)
+
+		if isAbrupt(key) {
+			return key
+		}
+
+		hashKey, ok := key.(object.Hashable)
+
+		if !ok {
+			return newError("unusable as hash key: %s", typeOf(key))
+		}
+
+		value := Eval(pair.Value, env)
+
+		if isAbrupt(value) {
+			return value
+		}
+
+		hash.Set(hashKey, value)
+	}
+
+	return hash
+}
+
+func evalExpressions(exps []ast.Expression, env *object.Environment) []object.Object {
+	result := []object.Object{}
+
+	for _, e := range exps {
+		evaluated := Eval(e, env)
+
+		if isAbrupt(evaluated) {
+			return []object.Object{evaluated}
+		}
+
+		result = append(result, evaluated)
+	}
+
+	return result
+}
+
+type namedArgument struct {
+	name  string
+	value object.Object
+}
+
+func evalNamedArguments(named []ast.NamedArgument, env *object.Environment) ([]namedArgument, object.Object) {
+	result := []namedArgument{}
+
+	for _, arg := range named {
+		val := Eval(arg.Value, env)
+
+		if isAbrupt(val) {
+			return nil, val
+		}
+
+		result = append(result, namedArgument{name: arg.Name.Value, value: val})
+	}
+
+	return result, nil
+}
+
+func applyFunction(fn object.Object, args []object.Object, named []namedArgument) object.Object {
+	switch fn := fn.(type) {
+	case *object.Function:
+		extendedEnv, abrupt := extendFunctionEnv(fn, args, named)
+
+		if abrupt != nil {
+			return unwrapReturnValue(abrupt)
+		}
+
+		evaluated := Eval(fn.Body, extendedEnv)
+		return unwrapReturnValue(evaluated)
+	case *object.Builtin:
+		if len(named) > 0 {
+			return newError("builtin functions don't accept named arguments")
+		}
+
+		if result := fn.Fn(args...); result != nil {
+			return result
+		}
+
+		return object.NULL
+	default:
+		return newError("not a function: %s", typeOf(fn))
+	}
+}
+
+// extendFunctionEnv binds the arguments of a call to the parameters of fn.
+// Positional arguments are bound first, then named ones. Parameters left
+// unbound get their default value, which is evaluated in the new environment
+// so that it can refer to the parameters before it. A default value that
+// errors or returns ends the call with that result.
+func extendFunctionEnv(fn *object.Function, args []object.Object, named []namedArgument) (*object.Environment, object.Object) {
+	extendedEnv := object.NewEnclosedEnvironment(fn.Env)
+	n := len(fn.Parameters)
+
+	if len(args) > n && fn.Rest == nil {
+		if required := requiredParameters(fn); required < n {
+			return nil, newError("wrong number of arguments. got=%d, want at most %d", len(args), n)
+		}
+
+		return nil, newError("wrong number of arguments. got=%d, want=%d", len(args), n)
+	}
+
+	bound := map[string]bool{}
+
+	for i, arg := range args {
+		if i == n {
+			break
+		}
+
+		extendedEnv.Set(fn.Parameters[i].Value, arg)
+		bound[fn.Parameters[i].Value] = true
+	}
+
+	if fn.Rest != nil {
+		rest := []object.Object{}
+
+		if len(args) > n {
+			rest = append(rest, args[n:]...)
+		}
+
+		extendedEnv.Set(fn.Rest.Value, &object.Array{Elements: rest})
+	}
+
+	for _, arg := range named {
+		if !hasParameter(fn, arg.name) {
+			return nil, newError("unexpected named argument %s", arg.name)
+		}
+
+		if bound[arg.name] {
+			return nil, newError("multiple values for parameter %s", arg.name)
+		}
+
+		extendedEnv.Set(arg.name, arg.value)
+		bound[arg.name] = true
+	}
+
+	for i, param := range fn.Parameters {
+		if bound[param.Value] {
+			continue
+		}
+
+		if i >= len(fn.Defaults) || fn.Defaults[i] == nil {
+			return nil, newError("missing argument for parameter %s", param.Value)
+		}
+
+		val := Eval(fn.Defaults[i], extendedEnv)
+
+		if isAbrupt(val) {
+			return nil, val
+		}
+
+		extendedEnv.Set(param.Value, val)
+	}
+
+	return extendedEnv, nil
+}
+
+func requiredParameters(fn *object.Function) int {
+	required := 0
+
+	for i := range fn.Parameters {
+		if i >= len(fn.Defaults) || fn.Defaults[i] == nil {
+			required++
+		}
+	}
+
+	return required
+}
+
+func hasParameter(fn *object.Function, name string) bool {
+	for _, param := range fn.Parameters {
+		if param.Value == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func unwrapReturnValue(obj object.Object) object.Object {
+	if returnValue, ok := obj.(*object.ReturnValue); ok {
+		return returnValue.Value
+	}
+
+	if obj == nil {
+		return object.NULL
+	}
+
+	return obj
+}
+
+func nativeBoolToBooleanObject(input bool) *object.Boolean {
+	if input {
+		return object.TRUE
+	}
+
+	return object.FALSE
+}
+
+func isTruthy(obj object.Object) bool {
+	switch obj {
+	case object.NULL:
+		return false
+	case object.TRUE:
+		return true
+	case object.FALSE:
+		return false
+	default:
+		return obj != nil
+	}
+}
+
+// isAbrupt reports whether obj ends the evaluation of the enclosing
+// expression early and has to be passed on unchanged.
+func isAbrupt(obj object.Object) bool {
+	if obj != nil {
+		switch obj.Type() {
+		case object.ERROR_OBJ, object.RETURN_VALUE_OBJ, object.BREAK_OBJ, object.CONTINUE_OBJ:
+			return true
+		}
+	}
+
+	return false
+}
+
+func newError(format string, a ...any) *object.Error {
+	return &object.Error{Message: fmt.Sprintf(format, a...), Kind: object.RUNTIME_ERROR}
+}
+
+func typeOf(obj object.Object) object.ObjectType {
+	if obj == nil {
+		return object.NULL_OBJ
+	}
+
+	return obj.Type()
+}
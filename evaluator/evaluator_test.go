@@ -0,0 +1,556 @@
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/evaluator"
+	"github.com/henningrck/monkey-interpreter/lexer"
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/henningrck/monkey-interpreter/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalIntegerExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"5", 5},
+		{"10", 10},
+		{"-5", -5},
+		{"-10", -10},
+		{"5 + 5 + 5 + 5 - 10", 10},
+		{"2 * 2 * 2 * 2 * 2", 32},
+		{"-50 + 100 + -50", 0},
+		{"5 * 2 + 10", 20},
+		{"5 + 2 * 10", 25},
+		{"20 + 2 * -10", 0},
+		{"50 / 2 * 2 + 10", 60},
+		{"2 * (5 + 10)", 30},
+		{"3 * 3 * 3 + 10", 37},
+		{"3 * (3 * 3) + 10", 37},
+		{"(5 + 10 * 2 + 15 / 3) * 2 + -10", 50},
+		{"7 % 3", 1},
+		{"-7 % 3", -1},
+		{"2 ** 10", 1024},
+		{"2 ** 3 ** 2", 512},
+		{"-2 ** 2", -4},
+		{"5 ** 0", 1},
+		{"12 & 10", 8},
+		{"12 | 10", 14},
+		{"12 ^ 10", 6},
+		{"~0", -1},
+		{"~5", -6},
+		{"1 << 10", 1024},
+		{"1024 >> 3", 128},
+		{"-16 >> 2", -4},
+		{"1 | 2 << 1", 5},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		checkIntegerObject(t, evaluated, test.expected)
+	}
+}
+
+func TestEvalFloatExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.5", 3.5},
+		{"-2.5", -2.5},
+		{"1.5 + 1.5", 3},
+		{"1 + 0.5", 1.5},
+		{"0.5 + 1", 1.5},
+		{"3 - 0.5", 2.5},
+		{"2 * 1.5", 3},
+		{"1.0 / 4", 0.25},
+		{"7 / 2.0", 3.5},
+		{"7.5 % 2", 1.5},
+		{"2 ** 0.5 ** 2", 1.189207115002721},
+		{"4.0 ** 0.5", 2},
+		{"1e3 + 1", 1001},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		result, ok := evaluated.(*object.Float)
+
+		if assert.True(t, ok, "object is not Float, got %T (%+v)", evaluated, evaluated) {
+			assert.InDelta(t, test.expected, result.Value, 1e-12, test.input)
+		}
+	}
+}
+
+func TestEvalBooleanExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"true", true},
+		{"false", false},
+		{"1 < 2", true},
+		{"1 > 2", false},
+		{"1 < 1", false},
+		{"1 > 1", false},
+		{"1 == 1", true},
+		{"1 != 1", false},
+		{"1 == 2", false},
+		{"1 != 2", true},
+		{"true == true", true},
+		{"false == false", true},
+		{"true == false", false},
+		{"true != false", true},
+		{"(1 < 2) == true", true},
+		{"(1 > 2) == true", false},
+		{"1 <= 2", true},
+		{"2 <= 2", true},
+		{"3 <= 2", false},
+		{"1 >= 2", false},
+		{"2 >= 2", true},
+		{"true && true", true},
+		{"true && false", false},
+		{"false || true", true},
+		{"false || false", false},
+		{"1 < 2 && 2 < 3", true},
+		{"1 > 2 || 2 > 3", false},
+		{"1 && 0", true},
+		{"if (false) { 1 } || true", true},
+		{"1 == 1.0", true},
+		{"1.5 != 1.5", false},
+		{"1 < 1.5", true},
+		{"2.5 >= 3", false},
+		{"0.1 + 0.2 > 0.3", true},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		checkBooleanObject(t, evaluated, test.expected)
+	}
+}
+
+func TestLogicalShortCircuit(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"false && undefined", false},
+		{"true || undefined", true},
+		{"false && 1 / 0", false},
+		{"let calls = fn() { boom() }; true || calls()", true},
+	}
+
+	for _, test := range tests {
+		checkBooleanObject(t, testEval(test.input), test.expected)
+	}
+
+	evaluated := testEval("true && undefined")
+	errObj, ok := evaluated.(*object.Error)
+
+	if assert.True(t, ok, "object is not Error, got %T (%+v)", evaluated, evaluated) {
+		assert.Equal(t, "identifier not found: undefined", errObj.Message)
+	}
+}
+
+func TestBangOperator(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"!true", false},
+		{"!false", true},
+		{"!5", false},
+		{"!!true", true},
+		{"!!false", false},
+		{"!!5", true},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		checkBooleanObject(t, evaluated, test.expected)
+	}
+}
+
+func TestStringExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`"Hello World!"`, "Hello World!"},
+		{`"Hello" + " " + "World!"`, "Hello World!"},
+		{`let greet = fn(name) { "Hello, " + name }; greet("Monkey")`, "Hello, Monkey"},
+		{`"a\tb"`, "a\tb"},
+		{`"monkey" == "monkey"`, true},
+		{`"monkey" == "donkey"`, false},
+		{`"monkey" != "donkey"`, true},
+		{`"a" + "b" == "ab"`, true},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+
+		switch expected := test.expected.(type) {
+		case string:
+			checkStringObject(t, evaluated, expected)
+		case bool:
+			checkBooleanObject(t, evaluated, expected)
+		}
+	}
+}
+
+func TestArrayLiterals(t *testing.T) {
+	evaluated := testEval("[1, 2 * 2, 3 + 3]")
+	result, ok := evaluated.(*object.Array)
+
+	if assert.True(t, ok, "object is not Array, got %T (%+v)", evaluated, evaluated) {
+		assert.Len(t, result.Elements, 3)
+		checkIntegerObject(t, result.Elements[0], 1)
+		checkIntegerObject(t, result.Elements[1], 4)
+		checkIntegerObject(t, result.Elements[2], 6)
+		assert.Equal(t, "[1, 4, 6]", result.Inspect())
+	}
+}
+
+func TestArrayIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"[1, 2, 3][0]", 1},
+		{"[1, 2, 3][1]", 2},
+		{"[1, 2, 3][2]", 3},
+		{"let i = 0; [1][i];", 1},
+		{"[1, 2, 3][1 + 1];", 3},
+		{"let myArray = [1, 2, 3]; myArray[2];", 3},
+		{"let myArray = [1, 2, 3]; myArray[0] + myArray[1] + myArray[2];", 6},
+		{"let myArray = [1, 2, 3]; let i = myArray[0]; myArray[i]", 2},
+		{"[1, 2, 3][3]", nil},
+		{"[1, 2, 3][-1]", 3},
+		{"[1, 2, 3][-3]", 1},
+		{"[1, 2, 3][-4]", nil},
+		{"[][0]", nil},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+
+		if integer, ok := test.expected.(int); ok {
+			checkIntegerObject(t, evaluated, int64(integer))
+		} else {
+			checkNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestHashLiterals(t *testing.T) {
+	input := `let two = "two";
+	{
+		"one": 10 - 9,
+		two: 1 + 1,
+		"thr" + "ee": 6 / 2,
+		4: 4,
+		true: 5,
+		false: 6
+	}`
+
+	evaluated := testEval(input)
+	result, ok := evaluated.(*object.Hash)
+
+	if !assert.True(t, ok, "object is not Hash, got %T (%+v)", evaluated, evaluated) {
+		return
+	}
+
+	expected := map[object.HashKey]int64{
+		(&object.String{Value: "one"}).HashKey():   1,
+		(&object.String{Value: "two"}).HashKey():   2,
+		(&object.String{Value: "three"}).HashKey(): 3,
+		(&object.Integer{Value: 4}).HashKey():      4,
+		object.TRUE.HashKey():                      5,
+		object.FALSE.HashKey():                     6,
+	}
+
+	assert.Len(t, result.Pairs, len(expected))
+
+	for key, value := range expected {
+		pair, ok := result.Pairs[key]
+
+		if assert.True(t, ok, "no pair for given key in pairs") {
+			checkIntegerObject(t, pair.Value, value)
+		}
+	}
+
+	assert.Equal(t, "{one: 1, two: 2, three: 3, 4: 4, true: 5, false: 6}", result.Inspect())
+}
+
+func TestHashIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{`{"foo": 5}["foo"]`, 5},
+		{`{"foo": 5}["bar"]`, nil},
+		{`let key = "foo"; {"foo": 5}[key]`, 5},
+		{`{}["foo"]`, nil},
+		{`{5: 5}[5]`, 5},
+		{`{true: 5}[true]`, 5},
+		{`{false: 5}[false]`, 5},
+		{`{"a": 1, "a": 2}["a"]`, 2},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+
+		if integer, ok := test.expected.(int); ok {
+			checkIntegerObject(t, evaluated, int64(integer))
+		} else {
+			checkNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestIfElseExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected any
+	}{
+		{"if (true) { 10 }", 10},
+		{"if (false) { 10 }", nil},
+		{"if (1) { 10 }", 10},
+		{"if (1 < 2) { 10 }", 10},
+		{"if (1 > 2) { 10 }", nil},
+		{"if (1 > 2) { 10 } else { 20 }", 20},
+		{"if (1 < 2) { 10 } else { 20 }", 10},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+
+		if integer, ok := test.expected.(int); ok {
+			checkIntegerObject(t, evaluated, int64(integer))
+		} else {
+			checkNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestReturnStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"return 10;", 10},
+		{"return 10; 9;", 10},
+		{"return 2 * 5; 9;", 10},
+		{"9; return 2 * 5; 9;", 10},
+		{
+			`if (10 > 1) {
+				if (10 > 1) {
+					return 10;
+				}
+
+				return 1;
+			}`,
+			10,
+		},
+		{
+			`let f = fn(x) {
+				if (x > 1) {
+					return x;
+				}
+
+				return 1;
+			};
+			f(5) + f(0);`,
+			6,
+		},
+		{"let f = fn() { let x = if (true) { return 5 }; 10 }; f()", 5},
+		{"fn() { [if (true) { return 1 }] }()", 1},
+		{"fn() { 1 + if (true) { return 1 } }()", 1},
+		{"fn() { -if (true) { return 1 } }()", 1},
+		{"fn() { {1: if (true) { return 1 }} }()", 1},
+		{"fn() { [1][if (true) { return 1 }] }()", 1},
+		{"fn() { let x = 0; x = if (true) { return 1 }; 10 }()", 1},
+		{"fn() { puts(if (true) { return 1 }); 10 }()", 1},
+		{"let f = fn(x) { x }; fn() { f(x: if (true) { return 1 }); 10 }()", 1},
+		{"fn(x = if (true) { return 1 }) { 10 }()", 1},
+		{"fn() { match (if (true) { return 1 }) { _ => 10 } }()", 1},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		checkIntegerObject(t, evaluated, test.expected)
+	}
+}
+
+func TestLetStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let a = 5; a;", 5},
+		{"let a = 5 * 5; a;", 25},
+		{"let a = 5; let b = a; b;", 5},
+		{"let a = 5; let b = a; let c = a + b + 5; c;", 15},
+		{"let größe = 5; let x2 = größe * 2; x2;", 10},
+	}
+
+	for _, test := range tests {
+		checkIntegerObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestFunctionObject(t *testing.T) {
+	input := "fn(x) { x + 2; };"
+
+	evaluated := testEval(input)
+	fn, ok := evaluated.(*object.Function)
+	assert.True(t, ok)
+	assert.Len(t, fn.Parameters, 1)
+	assert.Equal(t, "x", fn.Parameters[0].String())
+	assert.Equal(t, "(x + 2)", fn.Body.String())
+}
+
+func TestFunctionApplication(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let identity = fn(x) { x; }; identity(5);", 5},
+		{"let identity = fn(x) { return x; }; identity(5);", 5},
+		{"let double = fn(x) { x * 2; }; double(5);", 10},
+		{"let add = fn(x, y) { x + y; }; add(5, 5);", 10},
+		{"let add = fn(x, y) { x + y; }; add(5 + 5, add(5, 5));", 20},
+		{"fn(x) { x; }(5)", 5},
+		{"let fact = fn(n) { if (n < 2) { return 1; } n * fact(n - 1); }; fact(5);", 120},
+	}
+
+	for _, test := range tests {
+		checkIntegerObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestClosures(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{
+			`let newAdder = fn(x) { fn(y) { x + y } };
+			let addTwo = newAdder(2);
+			addTwo(3);`,
+			5,
+		},
+		{
+			`let add = fn(x) { fn(y) { fn(z) { x + y + z } } };
+			add(1)(2)(3);`,
+			6,
+		},
+		{
+			`let apply = fn(f, x) { f(x) };
+			let k = 10;
+			apply(fn(y) { y * k }, 4);`,
+			40,
+		},
+		{
+			`let x = 1;
+			let getX = fn() { x };
+			let shadow = fn(x) { getX() };
+			shadow(99);`,
+			1,
+		},
+	}
+
+	for _, test := range tests {
+		checkIntegerObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestErrorHandling(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"5 + true;", "type mismatch: INTEGER + BOOLEAN"},
+		{"5 + true; 5;", "type mismatch: INTEGER + BOOLEAN"},
+		{"-true", "unknown operator: -BOOLEAN"},
+		{"true + false;", "unknown operator: BOOLEAN + BOOLEAN"},
+		{"5; true + false; 5", "unknown operator: BOOLEAN + BOOLEAN"},
+		{"if (10 > 1) { true + false; }", "unknown operator: BOOLEAN + BOOLEAN"},
+		{
+			`if (10 > 1) {
+				if (10 > 1) {
+					return true + false;
+				}
+
+				return 1;
+			}`,
+			"unknown operator: BOOLEAN + BOOLEAN",
+		},
+		{"foobar", "identifier not found: foobar"},
+		{"10 / 0", "division by zero"},
+		{"10 % 0", "modulo by zero"},
+		{"2 ** -1", "negative exponent: -1"},
+		{"1.5 / 0", "division by zero"},
+		{"1 % 0.0", "modulo by zero"},
+		{"1.5 & 1", "unknown operator: FLOAT & INTEGER"},
+		{"1.5 + true", "type mismatch: FLOAT + BOOLEAN"},
+		{"1 << -1", "negative shift count: -1"},
+		{"~true", "unknown operator: ~BOOLEAN"},
+		{"true & false", "unknown operator: BOOLEAN & BOOLEAN"},
+		{"5(1)", "not a function: INTEGER"},
+		{`"Hello" - "World"`, "unknown operator: STRING - STRING"},
+		{`"Hello" + 1`, "type mismatch: STRING + INTEGER"},
+		{`[1, 2]["a"]`, "array index must be INTEGER, got STRING"},
+		{`5[0]`, "index operator not supported: INTEGER"},
+		{`{"name": "Monkey"}[fn(x) { x }];`, "unusable as hash key: FUNCTION"},
+		{`{[1]: 2}`, "unusable as hash key: ARRAY"},
+		{"let f = fn(x) { x }; f(unknown)", "identifier not found: unknown"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+
+		if assert.True(t, ok, "no error object returned, got %T (%+v)", evaluated, evaluated) {
+			assert.Equal(t, test.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func testEval(input string) object.Object {
+	l := lexer.New(input)
+	p := parser.New(l)
+	program := p.ParseProgram()
+	env := object.NewEnvironment()
+
+	return evaluator.Eval(program, env)
+}
+
+func checkIntegerObject(t *testing.T, obj object.Object, expected int64) {
+	result, ok := obj.(*object.Integer)
+
+	if assert.True(t, ok, "object is not Integer, got %T (%+v)", obj, obj) {
+		assert.Equal(t, expected, result.Value)
+	}
+}
+
+func checkStringObject(t *testing.T, obj object.Object, expected string) {
+	result, ok := obj.(*object.String)
+
+	if assert.True(t, ok, "object is not String, got %T (%+v)", obj, obj) {
+		assert.Equal(t, expected, result.Value)
+	}
+}
+
+func checkBooleanObject(t *testing.T, obj object.Object, expected bool) {
+	result, ok := obj.(*object.Boolean)
+
+	if assert.True(t, ok, "object is not Boolean, got %T (%+v)", obj, obj) {
+		assert.Equal(t, expected, result.Value)
+	}
+}
+
+func checkNullObject(t *testing.T, obj object.Object) {
+	assert.Equal(t, object.NULL, obj)
+}
@@ -0,0 +1,206 @@
+package evaluator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/henningrck/monkey-interpreter/ast"
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/henningrck/monkey-interpreter/token"
+)
+
+func evalThrowStatement(node *ast.ThrowStatement, env *object.Environment) object.Object {
+	val := Eval(node.Value, env)
+
+	if isAbrupt(val) {
+		return val
+	}
+
+	return newThrownError(val)
+}
+
+// newThrownError turns a thrown value into an error. A string becomes the
+// message. A hash provides the message and optionally the kind and the stack,
+// which is also how a caught error is thrown again.
+func newThrownError(val object.Object) *object.Error {
+	switch val := val.(type) {
+	case *object.String:
+		return &object.Error{Message: val.Value, Kind: object.THROWN_ERROR}
+	case *object.Hash:
+		message, ok := hashString(val, "message")
+
+		if !ok {
+			return newError("thrown hash must have a STRING message")
+		}
+
+		kind, ok := hashString(val, "kind")
+
+		if !ok {
+			kind = object.THROWN_ERROR
+		}
+
+		return &object.Error{Message: message, Kind: kind, Stack: hashStack(val)}
+	default:
+		return newError("cannot throw %s", typeOf(val))
+	}
+}
+
+// evalTryExpression evaluates to the value of the try block, or of the catch
+// block if the try block fails. The finally block always runs afterwards; its
+// value is discarded unless it fails or leaves the enclosing function or loop.
+func evalTryExpression(node *ast.TryExpression, env *object.Environment) object.Object {
+	result := Eval(node.Block, env)
+
+	if err, ok := result.(*object.Error); ok && node.Catch != nil {
+		catchEnv := object.NewEnclosedEnvironment(env)
+		catchEnv.Set(node.CatchParameter.Value, errorToHash(err))
+		result = Eval(node.Catch, catchEnv)
+	}
+
+	if node.Finally != nil {
+		finally := Eval(node.Finally, env)
+
+		switch finally.(type) {
+		case *object.Error, *object.ReturnValue, *object.Break, *object.Continue:
+			return finally
+		}
+	}
+
+	return result
+}
+
+// errorToHash converts a caught error to the hash a catch block sees, with
+// the keys message, kind and stack.
+func errorToHash(err *object.Error) *object.Hash {
+	stack := []object.Object{}
+
+	for _, frame := range err.Stack {
+		stack = append(stack, &object.String{Value: frame.String()})
+	}
+
+	kind := err.Kind
+
+	if kind == "" {
+		kind = object.RUNTIME_ERROR
+	}
+
+	hash := object.NewHash()
+	hash.Set(&object.String{Value: "message"}, &object.String{Value: err.Message})
+	hash.Set(&object.String{Value: "kind"}, &object.String{Value: kind})
+	hash.Set(&object.String{Value: "stack"}, &object.Array{Elements: stack})
+	return hash
+}
+
+// hashStack reads back the stack of a thrown hash, as errorToHash wrote it
+// for a caught error. Entries that aren't stack frames are left out.
+func hashStack(hash *object.Hash) []object.StackFrame {
+	val, ok := hash.Get(&object.String{Value: "stack"})
+
+	if !ok {
+		return nil
+	}
+
+	array, ok := val.(*object.Array)
+
+	if !ok {
+		return nil
+	}
+
+	var stack []object.StackFrame
+
+	for _, el := range array.Elements {
+		if str, ok := el.(*object.String); ok {
+			if frame, ok := parseStackFrame(str.Value); ok {
+				stack = append(stack, frame)
+			}
+		}
+	}
+
+	return stack
+}
+
+// parseStackFrame parses a stack frame in the form of StackFrame.String, such
+// as "f (1:38)" or "f (main.mk:1:38)".
+func parseStackFrame(s string) (object.StackFrame, bool) {
+	i := strings.LastIndex(s, " (")
+
+	if i < 0 || !strings.HasSuffix(s, ")") {
+		return object.StackFrame{}, false
+	}
+
+	frame := object.StackFrame{Function: s[:i]}
+	pos := s[i+2 : len(s)-1]
+
+	if pos == "-" {
+		return frame, true
+	}
+
+	parts := strings.Split(pos, ":")
+
+	if len(parts) < 2 {
+		return object.StackFrame{}, false
+	}
+
+	line, err := strconv.Atoi(parts[len(parts)-2])
+
+	if err != nil {
+		return object.StackFrame{}, false
+	}
+
+	column, err := strconv.Atoi(parts[len(parts)-1])
+
+	if err != nil {
+		return object.StackFrame{}, false
+	}
+
+	frame.Pos = token.Position{Filename: strings.Join(parts[:len(parts)-2], ":"), Line: line, Column: column}
+	return frame, true
+}
+
+// addStackFrame records that err passed through the call node on its way up.
+// The frame points at the called function rather than the call's parenthesis.
+func addStackFrame(err *object.Error, node *ast.CallExpression) {
+	name := node.Function.String()
+
+	if _, ok := node.Function.(*ast.FunctionLiteral); ok {
+		name = "fn"
+	}
+
+	err.Stack = append(err.Stack, object.StackFrame{Function: name, Pos: calleePos(node)})
+}
+
+// calleePos returns the position where the function of a call starts.
+func calleePos(node *ast.CallExpression) token.Position {
+	fn := node.Function
+
+	for {
+		switch expr := fn.(type) {
+		case *ast.Identifier:
+			return expr.Token.Pos
+		case *ast.FunctionLiteral:
+			return expr.Token.Pos
+		case *ast.CallExpression:
+			fn = expr.Function
+		case *ast.IndexExpression:
+			fn = expr.Left
+		default:
+			return node.Token.Pos
+		}
+	}
+}
+
+func hashString(hash *object.Hash, key string) (string, bool) {
+	val, ok := hash.Get(&object.String{Value: key})
+
+	if !ok {
+		return "", false
+	}
+
+	str, ok := val.(*object.String)
+
+	if !ok {
+		return "", false
+	}
+
+	return str.Value, true
+}
@@ -0,0 +1,153 @@
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/henningrck/monkey-interpreter/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrow(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+		expectedKind    string
+	}{
+		{`throw "bad input"`, "bad input", object.THROWN_ERROR},
+		{`throw {"message": "bad input", "kind": "ValueError"}`, "bad input", "ValueError"},
+		{`throw {"message": "bad input"}`, "bad input", object.THROWN_ERROR},
+		{`throw 5`, "cannot throw INTEGER", object.RUNTIME_ERROR},
+		{`throw {"kind": "ValueError"}`, "thrown hash must have a STRING message", object.RUNTIME_ERROR},
+		{`throw unknown`, "identifier not found: unknown", object.RUNTIME_ERROR},
+		{`let f = fn() { throw "inner"; 1 }; f() + 1`, "inner", object.THROWN_ERROR},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+
+		if assert.True(t, ok, "no error object returned, got %T (%+v)", evaluated, evaluated) {
+			assert.Equal(t, test.expectedMessage, errObj.Message)
+			assert.Equal(t, test.expectedKind, errObj.Kind)
+		}
+	}
+}
+
+func TestStackTrace(t *testing.T) {
+	input := `let check = fn(x) {
+	if (x < 0) { throw "negative" }
+	x
+};
+let run = fn() {
+	check(-1)
+};
+run();`
+
+	evaluated := testEval(input)
+	errObj, ok := evaluated.(*object.Error)
+
+	if assert.True(t, ok, "no error object returned, got %T (%+v)", evaluated, evaluated) {
+		assert.Equal(t, []object.StackFrame{
+			{Function: "check", Pos: token.Position{Line: 6, Column: 2, Offset: 77}},
+			{Function: "run", Pos: token.Position{Line: 8, Column: 1, Offset: 90}},
+		}, errObj.Stack)
+	}
+
+	evaluated = testEval(`len(1, 2)`)
+	errObj, ok = evaluated.(*object.Error)
+
+	if assert.True(t, ok, "no error object returned, got %T (%+v)", evaluated, evaluated) {
+		assert.Len(t, errObj.Stack, 1)
+		assert.Equal(t, "len (1:1)", errObj.Stack[0].String())
+	}
+}
+
+func TestRethrowKeepsStackTrace(t *testing.T) {
+	input := `let inner = fn() { throw "bad" };
+let outer = fn() { inner() };
+let wrap = fn() { try { outer() } catch (e) { throw e } };
+wrap();`
+
+	evaluated := testEval(input)
+	errObj, ok := evaluated.(*object.Error)
+
+	if assert.True(t, ok, "no error object returned, got %T (%+v)", evaluated, evaluated) {
+		assert.Equal(t, "bad", errObj.Message)
+		assert.Equal(t, []object.StackFrame{
+			{Function: "inner", Pos: token.Position{Line: 2, Column: 20}},
+			{Function: "outer", Pos: token.Position{Line: 3, Column: 25}},
+			{Function: "wrap", Pos: token.Position{Line: 4, Column: 1, Offset: 123}},
+		}, errObj.Stack)
+	}
+}
+
+func TestTryCatch(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`try { 1 } catch (e) { 2 }`, "1"},
+		{`try { throw "bad" } catch (e) { e["message"] }`, "bad"},
+		{`try { throw "bad" } catch (e) { e["kind"] }`, "Error"},
+		{`try { 1 + true } catch (e) { e["message"] }`, "type mismatch: INTEGER + BOOLEAN"},
+		{`try { 1 + true } catch (e) { e["kind"] }`, "RuntimeError"},
+		{`try { throw {"message": "m", "kind": "ValueError"} } catch (e) { e["kind"] }`, "ValueError"},
+		{`let f = fn() { throw "bad" }; try { f() } catch (e) { e["stack"] }`, "[f (1:37)]"},
+		{`let x = try { throw "bad" } catch (e) { 0 }; x + 1`, "1"},
+		{`try { throw "bad" } catch (e) { 1 }; e`, "ERROR: identifier not found: e"},
+		{
+			`try {
+				try { throw "inner" } catch (e) { throw e }
+			} catch (e) {
+				e["message"]
+			}`,
+			"inner",
+		},
+		{
+			`try {
+				try { throw "inner" } catch (e) { throw "outer" }
+			} catch (e) {
+				e["message"]
+			}`,
+			"outer",
+		},
+		{
+			`let parse = fn(s) {
+				match (s) {
+					"1" => 1,
+					_ => if (true) { throw {"message": "not a number: " + s, "kind": "ParseError"} },
+				}
+			};
+			let safe = fn(s) { try { parse(s) } catch (e) { match (e) { {"kind": "ParseError"} => -1 } } };
+			[safe("1"), safe("x")]`,
+			"[1, -1]",
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, testEval(test.input).Inspect(), test.input)
+	}
+}
+
+func TestTryFinally(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let log = []; try { log = push(log, 1) } finally { log = push(log, 2) }; log`, "[1, 2]"},
+		{`let x = try { 1 } finally { 2 }; x`, "1"},
+		{`let log = []; try { throw "bad" } catch (e) { log = push(log, 1) } finally { log = push(log, 2) }; log`, "[1, 2]"},
+		{`let log = []; try { try { throw "bad" } finally { log = push(log, 1) } } catch (e) { log = push(log, e["message"]) }; log`, "[1, bad]"},
+		{`try { throw "bad" } finally { 1 }`, "ERROR: Error: bad"},
+		{`try { 1 } finally { throw "from finally" }`, "ERROR: Error: from finally"},
+		{`try { throw "bad" } catch (e) { throw "again" } finally { 1 }`, "ERROR: Error: again"},
+		{`let f = fn() { try { return 1 } finally { 2 }; 3 }; f()`, "1"},
+		{`let f = fn() { try { return 1 } finally { return 2 } }; f()`, "2"},
+		{`let n = 0; let log = []; while (n < 3) { n += 1; try { if (n == 2) { break } } finally { log = push(log, n) } }; log`, "[1, 2]"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, testEval(test.input).Inspect(), test.input)
+	}
+}
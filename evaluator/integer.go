@@ -0,0 +1,261 @@
+package evaluator
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/henningrck/monkey-interpreter/ast"
+	"github.com/henningrck/monkey-interpreter/object"
+)
+
+// StrictIntegers turns integer overflow into a runtime error. By default, a
+// result that doesn't fit in 64 bits is promoted to an arbitrary-precision
+// integer instead, and demoted again once it fits.
+var StrictIntegers = false
+
+// maxIntegerBits bounds the size of arbitrary-precision results, so that an
+// expression like 2 ** 2 ** 40 fails instead of exhausting memory.
+const maxIntegerBits = 1 << 20
+
+func evalIntegerLiteral(node *ast.IntegerLiteral) object.Object {
+	if node.Big == nil {
+		return &object.Integer{Value: node.Value}
+	}
+
+	if StrictIntegers {
+		return newError("integer overflow: literal %s does not fit in 64 bits", node.Token.Literal)
+	}
+
+	return &object.BigInteger{Value: node.Big}
+}
+
+// evalIntegerInfixExpression computes on int64 values as long as the result
+// fits, and hands over to evalBigIntegerInfixExpression otherwise.
+func evalIntegerInfixExpression(operator string, left, right object.Object) object.Object {
+	leftInt, leftOk := left.(*object.Integer)
+	rightInt, rightOk := right.(*object.Integer)
+
+	if !leftOk || !rightOk {
+		return evalBigIntegerInfixExpression(operator, toBigInt(left), toBigInt(right))
+	}
+
+	leftVal := leftInt.Value
+	rightVal := rightInt.Value
+
+	switch operator {
+	case "+":
+		if result := leftVal + rightVal; (result > leftVal) == (rightVal > 0) {
+			return &object.Integer{Value: result}
+		}
+	case "-":
+		if result := leftVal - rightVal; (result < leftVal) == (rightVal > 0) {
+			return &object.Integer{Value: result}
+		}
+	case "*":
+		if result, ok := mulInt64(leftVal, rightVal); ok {
+			return &object.Integer{Value: result}
+		}
+	case "/":
+		if rightVal == 0 {
+			return newError("division by zero")
+		}
+
+		if leftVal != math.MinInt64 || rightVal != -1 {
+			return &object.Integer{Value: leftVal / rightVal}
+		}
+	case "%":
+		if rightVal == 0 {
+			return newError("modulo by zero")
+		}
+
+		return &object.Integer{Value: leftVal % rightVal}
+	case "**":
+		if rightVal < 0 {
+			return newError("negative exponent: %d", rightVal)
+		}
+
+		if result, ok := powInt64(leftVal, rightVal); ok {
+			return &object.Integer{Value: result}
+		}
+	case "&":
+		return &object.Integer{Value: leftVal & rightVal}
+	case "|":
+		return &object.Integer{Value: leftVal | rightVal}
+	case "^":
+		return &object.Integer{Value: leftVal ^ rightVal}
+	case "<<":
+		if rightVal < 0 {
+			return newError("negative shift count: %d", rightVal)
+		}
+
+		if rightVal < 63 && (leftVal<<rightVal)>>rightVal == leftVal {
+			return &object.Integer{Value: leftVal << rightVal}
+		}
+
+		if leftVal == 0 {
+			return &object.Integer{Value: 0}
+		}
+	case ">>":
+		if rightVal < 0 {
+			return newError("negative shift count: %d", rightVal)
+		}
+
+		return &object.Integer{Value: leftVal >> rightVal}
+	case "<":
+		return nativeBoolToBooleanObject(leftVal < rightVal)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal > rightVal)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal <= rightVal)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal >= rightVal)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal != rightVal)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+
+	return evalBigIntegerInfixExpression(operator, toBigInt(left), toBigInt(right))
+}
+
+func evalBigIntegerInfixExpression(operator string, leftVal, rightVal *big.Int) object.Object {
+	switch operator {
+	case "+":
+		return newInteger(new(big.Int).Add(leftVal, rightVal))
+	case "-":
+		return newInteger(new(big.Int).Sub(leftVal, rightVal))
+	case "*":
+		return newInteger(new(big.Int).Mul(leftVal, rightVal))
+	case "/":
+		if rightVal.Sign() == 0 {
+			return newError("division by zero")
+		}
+
+		return newInteger(new(big.Int).Quo(leftVal, rightVal))
+	case "%":
+		if rightVal.Sign() == 0 {
+			return newError("modulo by zero")
+		}
+
+		return newInteger(new(big.Int).Rem(leftVal, rightVal))
+	case "**":
+		if rightVal.Sign() < 0 {
+			return newError("negative exponent: %s", rightVal)
+		}
+
+		if leftVal.CmpAbs(big.NewInt(1)) > 0 && (!rightVal.IsInt64() || rightVal.Int64() > maxIntegerBits/int64(leftVal.BitLen()-1)) {
+			return newError("integer too large: %s ** %s", leftVal, rightVal)
+		}
+
+		return newInteger(new(big.Int).Exp(leftVal, rightVal, nil))
+	case "&":
+		return newInteger(new(big.Int).And(leftVal, rightVal))
+	case "|":
+		return newInteger(new(big.Int).Or(leftVal, rightVal))
+	case "^":
+		return newInteger(new(big.Int).Xor(leftVal, rightVal))
+	case "<<":
+		if rightVal.Sign() < 0 {
+			return newError("negative shift count: %s", rightVal)
+		}
+
+		if leftVal.Sign() == 0 {
+			return &object.Integer{Value: 0}
+		}
+
+		if !rightVal.IsInt64() || rightVal.Int64() > maxIntegerBits-int64(leftVal.BitLen()) {
+			return newError("integer too large: %s << %s", leftVal, rightVal)
+		}
+
+		return newInteger(new(big.Int).Lsh(leftVal, uint(rightVal.Int64())))
+	case ">>":
+		if rightVal.Sign() < 0 {
+			return newError("negative shift count: %s", rightVal)
+		}
+
+		if !rightVal.IsInt64() || rightVal.Int64() > maxIntegerBits {
+			return newInteger(big.NewInt(int64(leftVal.Sign() >> 1)))
+		}
+
+		return newInteger(new(big.Int).Rsh(leftVal, uint(rightVal.Int64())))
+	case "<":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) < 0)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) > 0)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) <= 0)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) >= 0)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) == 0)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) != 0)
+	default:
+		return newError("unknown operator: %s %s %s", object.INTEGER_OBJ, operator, object.INTEGER_OBJ)
+	}
+}
+
+// newInteger returns value as an Integer when it fits in 64 bits. Otherwise
+// it returns a BigInteger, or an overflow error when StrictIntegers is set.
+func newInteger(value *big.Int) object.Object {
+	if value.IsInt64() {
+		return &object.Integer{Value: value.Int64()}
+	}
+
+	if StrictIntegers {
+		return newError("integer overflow: result does not fit in 64 bits")
+	}
+
+	return &object.BigInteger{Value: value}
+}
+
+func toBigInt(obj object.Object) *big.Int {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return big.NewInt(obj.Value)
+	case *object.BigInteger:
+		return obj.Value
+	default:
+		return new(big.Int)
+	}
+}
+
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	result := a * b
+
+	if result/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+
+	return result, true
+}
+
+func powInt64(base, exp int64) (int64, bool) {
+	result := int64(1)
+
+	for exp > 0 {
+		var ok bool
+
+		if exp&1 == 1 {
+			if result, ok = mulInt64(result, base); !ok {
+				return 0, false
+			}
+		}
+
+		exp >>= 1
+
+		if exp > 0 {
+			if base, ok = mulInt64(base, base); !ok {
+				return 0, false
+			}
+		}
+	}
+
+	return result, true
+}
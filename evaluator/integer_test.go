@@ -0,0 +1,120 @@
+package evaluator_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/evaluator"
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegerOverflowPromotion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"-9223372036854775807 - 2", "-9223372036854775809"},
+		{"9223372036854775808", "9223372036854775808"},
+		{"0x1_0000_0000_0000_0000", "18446744073709551616"},
+		{"4611686018427387904 * 2", "9223372036854775808"},
+		{"-9223372036854775808 / -1", "9223372036854775808"},
+		{"-(-9223372036854775808)", "9223372036854775808"},
+		{"2 ** 64", "18446744073709551616"},
+		{"3 ** 50", "717897987691852588770249"},
+		{"1 << 64", "18446744073709551616"},
+		{"-1 << 63", "-9223372036854775808"},
+		{"~9223372036854775808", "-9223372036854775809"},
+		{"2 ** 64 - 2 ** 64", "0"},
+		{"(2 ** 64) / (2 ** 60)", "16"},
+		{"(2 ** 64 + 5) % 2 ** 64", "5"},
+		{"(2 ** 64) >> 60", "16"},
+		{"(2 ** 65) & (2 ** 65 + 1)", "36893488147419103232"},
+		{"-(2 ** 64) >> 1000000000000", "-1"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+
+		if assert.Equal(t, object.ObjectType(object.INTEGER_OBJ), evaluated.Type(), test.input) {
+			assert.Equal(t, test.expected, evaluated.Inspect(), test.input)
+		}
+	}
+}
+
+func TestBigIntegersDemote(t *testing.T) {
+	evaluated := testEval("(2 ** 64) / (2 ** 60)")
+	checkIntegerObject(t, evaluated, 16)
+
+	evaluated = testEval("-9223372036854775808")
+	checkIntegerObject(t, evaluated, -9223372036854775808)
+}
+
+func TestBigIntegerComparisons(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"2 ** 64 == 2 ** 64", true},
+		{"2 ** 64 != 2 ** 64 + 1", true},
+		{"2 ** 64 > 9223372036854775807", true},
+		{"-(2 ** 64) < -9223372036854775808", true},
+		{"2 ** 64 <= 1.9e19", true},
+		{"{2 ** 64: true}[18446744073709551616]", true},
+	}
+
+	for _, test := range tests {
+		checkBooleanObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestIntegerLimits(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"2 ** 2 ** 40", "integer too large: 2 ** 1099511627776"},
+		{"1 << 2 ** 40", "integer too large: 1 << 1099511627776"},
+		{"1 << 9223372036854775807", "integer too large: 1 << 9223372036854775807"},
+		{"(2 ** 64) ** (2 ** 58)", "integer too large: 18446744073709551616 ** 288230376151711744"},
+		{"(2 ** 1000) ** (2 ** 60)", "integer too large: " + new(big.Int).Lsh(big.NewInt(1), 1000).String() + " ** 1152921504606846976"},
+		{"9223372036854775807 ** 9223372036854775807", "integer too large: 9223372036854775807 ** 9223372036854775807"},
+		{"2 ** 64 / 0", "division by zero"},
+		{"2 ** 64 % 0", "modulo by zero"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+
+		if assert.True(t, ok, "object is not Error, got %T (%+v)", evaluated, evaluated) {
+			assert.Equal(t, test.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestStrictIntegers(t *testing.T) {
+	evaluator.StrictIntegers = true
+	defer func() { evaluator.StrictIntegers = false }()
+
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"9223372036854775807 + 1", "integer overflow: result does not fit in 64 bits"},
+		{"2 ** 63", "integer overflow: result does not fit in 64 bits"},
+		{"9223372036854775808", "integer overflow: literal 9223372036854775808 does not fit in 64 bits"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+
+		if assert.True(t, ok, "object is not Error, got %T (%+v)", evaluated, evaluated) {
+			assert.Equal(t, test.expectedMessage, errObj.Message)
+		}
+	}
+
+	checkIntegerObject(t, testEval("9223372036854775806 + 1"), 9223372036854775807)
+}
@@ -0,0 +1,96 @@
+package evaluator
+
+import (
+	"github.com/henningrck/monkey-interpreter/ast"
+	"github.com/henningrck/monkey-interpreter/object"
+)
+
+// evalWhileStatement runs each iteration of the body in its own scope, like
+// evalForStatement, so bindings made with let don't outlive the iteration.
+// Assignments still reach the enclosing scopes.
+func evalWhileStatement(node *ast.WhileStatement, env *object.Environment) object.Object {
+	for {
+		condition := Eval(node.Condition, env)
+
+		if isAbrupt(condition) {
+			return condition
+		}
+
+		if !isTruthy(condition) {
+			return nil
+		}
+
+		result := Eval(node.Body, object.NewEnclosedEnvironment(env))
+
+		if stop, value := loopControl(result); stop {
+			return value
+		}
+	}
+}
+
+func evalForStatement(node *ast.ForStatement, env *object.Environment) object.Object {
+	iterable := Eval(node.Iterable, env)
+
+	if isAbrupt(iterable) {
+		return iterable
+	}
+
+	items, err := iterate(iterable)
+
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		loopEnv := object.NewEnclosedEnvironment(env)
+		loopEnv.Set(node.Variable.Value, item)
+		result := Eval(node.Body, loopEnv)
+
+		if stop, value := loopControl(result); stop {
+			return value
+		}
+	}
+
+	return nil
+}
+
+// loopControl inspects the result of a loop body. It reports whether the loop
+// has to stop and what the loop statement evaluates to in that case: a return
+// value or error is passed on, a break ends the loop quietly.
+func loopControl(result object.Object) (bool, object.Object) {
+	switch result.(type) {
+	case *object.ReturnValue, *object.Error:
+		return true, result
+	case *object.Break:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// iterate returns the items a for loop visits: the elements of an array, the
+// characters of a string or the keys of a hash in insertion order.
+func iterate(obj object.Object) ([]object.Object, *object.Error) {
+	switch obj := obj.(type) {
+	case *object.Array:
+		return obj.Elements, nil
+	case *object.String:
+		items := []object.Object{}
+
+		for _, r := range obj.Value {
+			items = append(items, &object.String{Value: string(r)})
+		}
+
+		return items, nil
+	case *object.Hash:
+		items := []object.Object{}
+
+		for _, pair := range obj.OrderedPairs() {
+			items = append(items, pair.Key)
+		}
+
+		return items, nil
+	default:
+		return nil, newError("cannot iterate over %s", typeOf(obj))
+	}
+}
@@ -0,0 +1,164 @@
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhileStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let i = 0; while (i < 10) { i += 1 }; i", 10},
+		{"let i = 0; while (false) { i += 1 }; i", 0},
+		{"let i = 0; while (true) { i += 1; if (i == 3) { break } }; i", 3},
+		{
+			`let i = 0;
+			let sum = 0;
+			while (i < 10) {
+				i += 1;
+				if (i % 2 == 0) { continue }
+				sum += i;
+			}
+			sum;`,
+			25,
+		},
+		{"let i = 0; while (i < 100000) { i += 1 }; i", 100000},
+		{"let i = 0; while (i < 3) { let i = 100; break }; i", 0},
+	}
+
+	for _, test := range tests {
+		checkIntegerObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestForStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let sum = 0; for (x in [1, 2, 3]) { sum += x }; sum", "6"},
+		{`let s = ""; for (c in "héllo") { s = c + s }; s`, "olléh"},
+		{`let ks = []; for (k in {"b": 1, "a": 2}) { ks = push(ks, k) }; ks`, "[b, a]"},
+		{"let n = 0; for (x in []) { n += 1 }; n", "0"},
+		{
+			`let out = [];
+			for (x in [1, 2, 3, 4, 5]) {
+				if (x == 2) { continue }
+				if (x == 4) { break }
+				out = push(out, x);
+			}
+			out;`,
+			"[1, 3]",
+		},
+		{
+			`let n = 0;
+			for (row in [[1, 2], [3, 4]]) {
+				for (x in row) {
+					if (x == 2) { break }
+					n += x;
+				}
+			}
+			n;`,
+			"8",
+		},
+		{
+			`let fns = [];
+			for (x in [1, 2]) { fns = push(fns, fn() { x }) }
+			fns[0]() + fns[1]() * 10;`,
+			"21",
+		},
+		{"let x = 7; for (x in [1, 2]) { }; x", "7"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, testEval(test.input).Inspect(), test.input)
+	}
+}
+
+func TestReturnInsideLoops(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{
+			`let find = fn(arr, target) {
+				for (x in arr) {
+					if (x == target) { return x * 10 }
+				}
+				return -1;
+			};
+			find([1, 2, 3], 2);`,
+			20,
+		},
+		{
+			`let f = fn() {
+				let i = 0;
+				while (true) {
+					i += 1;
+					if (i == 5) { return i }
+				}
+			};
+			f();`,
+			5,
+		},
+		{
+			`let f = fn() {
+				for (x in [1, 2]) {
+					while (true) { return x }
+				}
+			};
+			f() + 1;`,
+			2,
+		},
+	}
+
+	for _, test := range tests {
+		checkIntegerObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestLoopControlInsideExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"let i = 0; while (i < 1000) { i += 1; let x = if (true) { break }; }; i", 1},
+		{"let i = 0; let x = 0; while (i < 3) { i += 1; x = if (true) { break } }; x", 0},
+		{"let sum = 0; for (x in [1, 2, 3]) { sum += if (x == 2) { continue } else { x } }; sum", 4},
+		{"let id = fn(v) { v }; let n = 0; for (x in [1, 2, 3]) { id(if (x < 3) { continue }); n += 1 }; n", 1},
+		{"let n = 0; for (x in [1, 2]) { n += len([if (true) { break }]) }; n", 0},
+		{"let n = 0; for (x in [1, 2]) { n += 1 + if (true) { continue } }; n", 0},
+		{"let n = 0; for (x in [1, 2]) { n += {1: if (true) { break }}[1] }; n", 0},
+		{"let n = 0; for (x in [1, 2]) { match (if (true) { break }) { _ => n += 1 } }; n", 0},
+	}
+
+	for _, test := range tests {
+		checkIntegerObject(t, testEval(test.input), test.expected)
+	}
+}
+
+func TestLoopErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"for (x in 5) { }", "cannot iterate over INTEGER"},
+		{"for (x in unknown) { }", "identifier not found: unknown"},
+		{"while (unknown) { }", "identifier not found: unknown"},
+		{"let i = 0; while (true) { i += 1; if (i > 2) { i + true } }", "type mismatch: INTEGER + BOOLEAN"},
+		{"for (x in [1]) { x + true }; 5", "type mismatch: INTEGER + BOOLEAN"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+
+		if assert.True(t, ok, "no error object returned, got %T (%+v)", evaluated, evaluated) {
+			assert.Equal(t, test.expectedMessage, errObj.Message)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package evaluator
+
+import (
+	"github.com/henningrck/monkey-interpreter/ast"
+	"github.com/henningrck/monkey-interpreter/object"
+)
+
+// evalMatchExpression evaluates the body of the first arm whose pattern
+// matches the subject. Each arm gets its own scope for the names its pattern
+// binds. Like an if without else, a match without a matching arm is null.
+func evalMatchExpression(node *ast.MatchExpression, env *object.Environment) object.Object {
+	subject := Eval(node.Subject, env)
+
+	if isAbrupt(subject) {
+		return subject
+	}
+
+	for _, arm := range node.Arms {
+		armEnv := object.NewEnclosedEnvironment(env)
+		matched, err := matchPattern(arm.Pattern, subject, armEnv)
+
+		if err != nil {
+			return err
+		}
+
+		if matched {
+			return Eval(arm.Body, armEnv)
+		}
+	}
+
+	return object.NULL
+}
+
+// matchPattern reports whether value matches pattern, and binds the names in
+// the pattern in env as it goes.
+func matchPattern(pattern ast.Pattern, value object.Object, env *object.Environment) (bool, *object.Error) {
+	switch pattern := pattern.(type) {
+	case *ast.WildcardPattern:
+		return true, nil
+	case *ast.BindingPattern:
+		env.Set(pattern.Name.Value, value)
+		return true, nil
+	case *ast.LiteralPattern:
+		literal := Eval(pattern.Value, env)
+
+		if err, ok := literal.(*object.Error); ok {
+			return false, err
+		}
+
+		return evalInfixExpression("==", literal, value) == object.TRUE, nil
+	case *ast.ArrayPattern:
+		return matchArrayPattern(pattern, value, env)
+	case *ast.HashPattern:
+		return matchHashPattern(pattern, value, env)
+	default:
+		return false, newError("unknown pattern: %s", pattern.String())
+	}
+}
+
+func matchArrayPattern(pattern *ast.ArrayPattern, value object.Object, env *object.Environment) (bool, *object.Error) {
+	array, ok := value.(*object.Array)
+
+	if !ok {
+		return false, nil
+	}
+
+	if pattern.Rest == nil && len(array.Elements) != len(pattern.Elements) {
+		return false, nil
+	}
+
+	if len(array.Elements) < len(pattern.Elements) {
+		return false, nil
+	}
+
+	for i, el := range pattern.Elements {
+		if matched, err := matchPattern(el, array.Elements[i], env); !matched || err != nil {
+			return false, err
+		}
+	}
+
+	bindRest(pattern, array.Elements, env)
+	return true, nil
+}
+
+func matchHashPattern(pattern *ast.HashPattern, value object.Object, env *object.Environment) (bool, *object.Error) {
+	hash, ok := value.(*object.Hash)
+
+	if !ok {
+		return false, nil
+	}
+
+	for _, pair := range pattern.Pairs {
+		element, err := lookupPatternKey(hash, pair.Key, env)
+
+		if element == nil || err != nil {
+			return false, err
+		}
+
+		if matched, err := matchPattern(pair.Value, element, env); !matched || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
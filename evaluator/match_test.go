@@ -0,0 +1,61 @@
+package evaluator_test
+
+import (
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`match (1) { 1 => "one", _ => "other" }`, "one"},
+		{`match (5) { 1 => "one", _ => "other" }`, "other"},
+		{`match (-3) { -3 => "minus three", _ => "other" }`, "minus three"},
+		{`match (2.0) { 2 => "two", _ => "other" }`, "two"},
+		{`match ("b") { "a" => 1, "b" => 2, _ => 3 }`, "2"},
+		{`match (false) { true => "yes", false => "no" }`, "no"},
+		{`match ("1") { 1 => "int", _ => "other" }`, "other"},
+		{"match (7) { n => n * 2 }", "14"},
+		{"match ([1, 2]) { [a, b] => a + b, _ => 0 }", "3"},
+		{"match ([1, 2, 3]) { [a, b] => a + b, [a, _, c] => a * c, _ => 0 }", "3"},
+		{"match ([1, [2, 3]]) { [1, [x, 3]] => x, _ => 0 }", "2"},
+		{"match ([]) { [] => \"empty\", _ => \"other\" }", "empty"},
+		{"match ([1, 2, 3]) { [] => 0, [x, ...xs] => xs }", "[2, 3]"},
+		{"match ([1]) { [a, b, ...rest] => 2, [a, ...rest] => rest }", "[]"},
+		{`match ({"name": "Ann", "age": 3}) { {name, age} => name + "!" }`, "Ann!"},
+		{`match ({"k": 4, "other": 1}) { {"k": v} => v, _ => 0 }`, "4"},
+		{`match ({"k": 4}) { {"k": 5} => "five", {"k": v} => v, _ => 0 }`, "4"},
+		{`match ({"x": 1}) { {"k": v} => v, _ => "missing" }`, "missing"},
+		{`match ("str") { [a] => a, {"k": v} => v, _ => "neither" }`, "neither"},
+		{"match (3) { 1 => 1 }", "null"},
+		{"let x = 10; match (1) { x => x }; x", "10"},
+		{"let f = fn(v) { match (v) { 0 => if (true) { return 100 }, n => n }; 5 }; f(0) + f(1)", "105"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, testEval(test.input).Inspect(), test.input)
+	}
+}
+
+func TestMatchErrors(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"match (unknown) { _ => 1 }", "identifier not found: unknown"},
+		{"match (1) { 1 => true + 1, _ => 2 }", "type mismatch: BOOLEAN + INTEGER"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+
+		if assert.True(t, ok, "no error object returned, got %T (%+v)", evaluated, evaluated) {
+			assert.Equal(t, test.expectedMessage, errObj.Message)
+		}
+	}
+}
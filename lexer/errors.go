@@ -0,0 +1,26 @@
+package lexer
+
+import (
+	"fmt"
+
+	"github.com/henningrck/monkey-interpreter/token"
+)
+
+// Error is a lexical error. The lexer emits an ILLEGAL token at the same
+// position, so the parser only has to skip that token.
+type Error struct {
+	Pos token.Position
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+func (l *Lexer) Errors() []*Error {
+	return l.errors
+}
+
+func (l *Lexer) addError(pos token.Position, format string, a ...any) {
+	l.errors = append(l.errors, &Error{Pos: pos, Msg: fmt.Sprintf(format, a...)})
+}
@@ -1,69 +1,243 @@
 package lexer
 
-import "github.com/henningrck/monkey-interpreter/token"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/henningrck/monkey-interpreter/token"
+)
+
+// Mode controls optional lexer behavior.
+type Mode uint
+
+const (
+	// ScanComments makes the lexer emit COMMENT tokens instead of skipping
+	// comments, so that tools such as formatters can preserve them.
+	ScanComments Mode = 1 << iota
+)
+
+const readChunkSize = 4096
+
+// Lexer turns source code into tokens. The input is a window of the source:
+// for a lexer reading from an io.Reader, only the current token and the
+// characters after it are buffered. position and readPosition are indices
+// into the window, and offset is the source offset of the window start.
 type Lexer struct {
-	input        string
+	input        []byte
+	reader       io.Reader
+	readErr      error
+	buf          []byte
+	offset       int
+	filename     string
+	mode         Mode
 	position     int
 	readPosition int
-	ch           byte
+	ch           rune
+	line         int
+	column       int
+	errors       []*Error
 }
 
 func New(input string) *Lexer {
-	l := &Lexer{input: input}
+	return NewWithFilename("", input)
+}
+
+func NewWithFilename(filename, input string) *Lexer {
+	l := &Lexer{input: []byte(input), filename: filename, line: 1}
+	l.readChar()
+	return l
+}
+
+// NewReader returns a lexer that reads the source incrementally from r, so
+// that the source never has to be held in memory as a whole.
+func NewReader(r io.Reader) *Lexer {
+	return NewReaderWithFilename("", r)
+}
+
+func NewReaderWithFilename(filename string, r io.Reader) *Lexer {
+	l := &Lexer{reader: r, buf: make([]byte, readChunkSize), filename: filename, line: 1}
 	l.readChar()
 	return l
 }
 
+func (l *Lexer) SetMode(mode Mode) {
+	l.mode = mode
+}
+
 func (l *Lexer) readChar() {
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	}
+
+	l.column++
+	l.position = l.readPosition
+	l.fill(utf8.UTFMax)
+
 	if l.readPosition >= len(l.input) {
+		if l.readErr != nil {
+			l.addError(l.pos(), "read error: %s", l.readErr)
+			l.readErr = nil
+		}
+
 		l.ch = 0
+		l.readPosition++
+		return
+	}
+
+	ch, width := utf8.DecodeRune(l.input[l.readPosition:])
+	l.ch = ch
+	l.readPosition += width
+
+	if l.invalidRune() {
+		l.addError(l.pos(), "invalid UTF-8 encoding")
+	}
+}
+
+// fill reads from the underlying reader until at least n bytes after the read
+// position are buffered or the reader is exhausted. A read error ends the
+// input and is reported once the lexer reaches that point.
+func (l *Lexer) fill(n int) {
+	for l.reader != nil && len(l.input)-l.readPosition < n {
+		count, err := l.reader.Read(l.buf)
+		l.input = append(l.input, l.buf[:count]...)
+
+		if err != nil {
+			if err != io.EOF {
+				l.readErr = err
+			}
+
+			l.reader = nil
+		}
+	}
+}
+
+// discard drops everything before the current character from the window.
+// It must only be called between tokens, since the lexer slices literals out
+// of the window. While reading, the rest of the window is moved to the front
+// once it is no longer than the dropped part, so the buffer is reused.
+func (l *Lexer) discard() {
+	if l.position > len(l.input) {
+		return
+	}
+
+	l.offset += l.position
+
+	if l.reader != nil && len(l.input)-l.position <= l.position {
+		l.input = l.input[:copy(l.input, l.input[l.position:])]
 	} else {
-		l.ch = l.input[l.readPosition]
+		l.input = l.input[l.position:]
 	}
 
-	l.position = l.readPosition
-	l.readPosition++
+	l.readPosition -= l.position
+	l.position = 0
+}
+
+// invalidRune reports whether the current character is a byte that is not
+// valid UTF-8, as opposed to a correctly encoded U+FFFD.
+func (l *Lexer) invalidRune() bool {
+	return l.ch == utf8.RuneError && l.readPosition-l.position == 1
 }
 
 func (l *Lexer) NextToken() token.Token {
 	var tok token.Token
 
+	l.discard()
 	l.skipWhitespace()
+	pos := l.pos()
 
 	switch l.ch {
 	case '=':
-		if l.peekChar() == '=' {
-			ch := l.ch
-			l.readChar()
-			tok = token.Token{Type: token.EQ, Literal: string(ch) + string(l.ch)}
-		} else {
+		switch l.peekChar() {
+		case '=':
+			tok = l.newTwoCharToken(token.EQ)
+		case '>':
+			tok = l.newTwoCharToken(token.ARROW)
+		default:
 			tok = newToken(token.ASSIGN, l.ch)
 		}
 	case '+':
-		tok = newToken(token.PLUS, l.ch)
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.PLUS_ASSIGN)
+		} else {
+			tok = newToken(token.PLUS, l.ch)
+		}
 	case '-':
-		tok = newToken(token.MINUS, l.ch)
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.MINUS_ASSIGN)
+		} else {
+			tok = newToken(token.MINUS, l.ch)
+		}
 	case '!':
 		if l.peekChar() == '=' {
-			ch := l.ch
-			l.readChar()
-			tok = token.Token{Type: token.NOT_EQ, Literal: string(ch) + string(l.ch)}
+			tok = l.newTwoCharToken(token.NOT_EQ)
 		} else {
 			tok = newToken(token.BANG, l.ch)
 		}
 	case '*':
-		tok = newToken(token.ASTERISK, l.ch)
+		switch l.peekChar() {
+		case '*':
+			tok = l.newTwoCharToken(token.POWER)
+		case '=':
+			tok = l.newTwoCharToken(token.ASTERISK_ASSIGN)
+		default:
+			tok = newToken(token.ASTERISK, l.ch)
+		}
+	case '%':
+		tok = newToken(token.PERCENT, l.ch)
+	case '^':
+		tok = newToken(token.BIT_XOR, l.ch)
+	case '~':
+		tok = newToken(token.BIT_NOT, l.ch)
 	case '/':
-		tok = newToken(token.SLASH, l.ch)
+		if l.atCommentStart() {
+			tok = l.readComment(pos)
+		} else if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.SLASH_ASSIGN)
+		} else {
+			tok = newToken(token.SLASH, l.ch)
+		}
 	case '<':
-		tok = newToken(token.LT, l.ch)
+		switch l.peekChar() {
+		case '=':
+			tok = l.newTwoCharToken(token.LT_EQ)
+		case '<':
+			tok = l.newTwoCharToken(token.SHL)
+		default:
+			tok = newToken(token.LT, l.ch)
+		}
 	case '>':
-		tok = newToken(token.GT, l.ch)
+		switch l.peekChar() {
+		case '=':
+			tok = l.newTwoCharToken(token.GT_EQ)
+		case '>':
+			tok = l.newTwoCharToken(token.SHR)
+		default:
+			tok = newToken(token.GT, l.ch)
+		}
+	case '&':
+		if l.peekChar() == '&' {
+			tok = l.newTwoCharToken(token.AND)
+		} else {
+			tok = newToken(token.BIT_AND, l.ch)
+		}
+	case '|':
+		if l.peekChar() == '|' {
+			tok = l.newTwoCharToken(token.OR)
+		} else {
+			tok = newToken(token.BIT_OR, l.ch)
+		}
 	case ',':
 		tok = newToken(token.COMMA, l.ch)
 	case ';':
 		tok = newToken(token.SEMICOLON, l.ch)
+	case ':':
+		tok = newToken(token.COLON, l.ch)
 	case '(':
 		tok = newToken(token.LPAREN, l.ch)
 	case ')':
@@ -72,6 +246,12 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.LBRACE, l.ch)
 	case '}':
 		tok = newToken(token.RBRACE, l.ch)
+	case '[':
+		tok = newToken(token.LBRACKET, l.ch)
+	case ']':
+		tok = newToken(token.RBRACKET, l.ch)
+	case '"':
+		tok = l.readString(pos)
 	case 0:
 		tok.Type = token.EOF
 		tok.Literal = ""
@@ -79,62 +259,412 @@ func (l *Lexer) NextToken() token.Token {
 		if isLetter(l.ch) {
 			tok.Literal = l.readIdentifier()
 			tok.Type = token.LookupIdent(tok.Literal)
+			tok.Pos = pos
 			return tok
 		} else if isDigit(l.ch) {
-			tok.Type = token.INT
-			tok.Literal = l.readNumber()
+			tok.Type, tok.Literal = l.readNumber(pos)
+			tok.Pos = pos
 			return tok
+		} else if l.atEllipsis() {
+			l.readChar()
+			l.readChar()
+			tok = token.Token{Type: token.ELLIPSIS, Literal: "..."}
+		} else if l.invalidRune() {
+			tok = token.Token{Type: token.ILLEGAL, Literal: string(l.input[l.position:l.readPosition])}
 		} else {
+			l.addError(pos, "illegal character %q", l.ch)
 			tok = newToken(token.ILLEGAL, l.ch)
 		}
 	}
 
+	tok.Pos = pos
 	l.readChar()
 	return tok
 }
 
+func (l *Lexer) pos() token.Position {
+	return token.Position{
+		Filename: l.filename,
+		Line:     l.line,
+		Column:   l.column,
+		Offset:   l.offset + l.position,
+	}
+}
+
 func (l *Lexer) readIdentifier() string {
 	position := l.position
 
-	for isLetter(l.ch) {
+	for isLetter(l.ch) || unicode.IsDigit(l.ch) {
 		l.readChar()
 	}
 
-	return l.input[position:l.position]
+	return string(l.input[position:l.position])
 }
 
-func (l *Lexer) readNumber() string {
+// readNumber reads an integer or a float literal. Integers may use a 0x, 0o
+// or 0b prefix for hexadecimal, octal or binary notation, but no other leading
+// zero. A float has a fraction, an exponent or both, as in 3.14, 1e-9 or
+// 2.5E+3. The dot must be followed by a digit, so 1. is not a float. Single
+// underscores may separate digits in all literals, as in 1_000_000 or
+// 0b1010_1010.
+func (l *Lexer) readNumber(pos token.Position) (token.TokenType, string) {
 	position := l.position
+	tokenType := token.TokenType(token.INT)
 
-	for isDigit(l.ch) {
+	if l.ch == '0' && isBasePrefix(l.peekChar()) {
+		l.readChar()
 		l.readChar()
+		l.readDigits(isAlphanumeric)
+	} else {
+		l.readDigits(isDigit)
+
+		if l.ch == '.' && isDigit(l.peekChar()) {
+			tokenType = token.FLOAT
+			l.readChar()
+			l.readDigits(isDigit)
+		}
+
+		if l.ch == 'e' || l.ch == 'E' {
+			tokenType = token.FLOAT
+			l.readChar()
+
+			if l.ch == '+' || l.ch == '-' {
+				l.readChar()
+			}
+
+			if !isDigit(l.ch) {
+				literal := string(l.input[position:l.position])
+				l.addError(pos, "malformed exponent in number literal %q", literal)
+				return token.ILLEGAL, literal
+			}
+
+			l.readDigits(isDigit)
+		}
+	}
+
+	if isLetter(l.ch) || isDigit(l.ch) {
+		l.readDigits(isAlphanumeric)
+		literal := string(l.input[position:l.position])
+		l.addError(pos, "malformed number literal %q", literal)
+		return token.ILLEGAL, literal
+	}
+
+	literal := string(l.input[position:l.position])
+
+	// Octal literals need the 0o prefix. A leading zero isn't allowed in
+	// decimal integers, so that 0755 isn't silently read as 755 or 493.
+	if tokenType == token.INT && len(literal) > 1 && literal[0] == '0' && !isBasePrefix(rune(literal[1])) {
+		l.addError(pos, "malformed number literal %q", literal)
+		return token.ILLEGAL, literal
 	}
 
-	return l.input[position:l.position]
+	if msg := checkNumber(literal); msg != "" {
+		l.addError(pos, "%s in number literal %q", msg, literal)
+		return token.ILLEGAL, literal
+	}
+
+	return tokenType, literal
 }
 
-func (l *Lexer) skipWhitespace() {
-	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
+func (l *Lexer) readDigits(valid func(rune) bool) {
+	for valid(l.ch) || l.ch == '_' {
 		l.readChar()
 	}
 }
 
-func (l *Lexer) peekChar() byte {
+// checkNumber validates the digits of a number literal against its base and
+// the placement of underscores, which may only appear between two digits or
+// right after a base prefix. It returns a description of the problem, or an
+// empty string if the literal is well-formed.
+func checkNumber(literal string) string {
+	base := 10
+	i := 0
+	last := byte('^')
+
+	if len(literal) >= 2 && literal[0] == '0' && isBasePrefix(rune(literal[1])) {
+		base = map[byte]int{'x': 16, 'o': 8, 'b': 2}[literal[1]|0x20]
+		i = 2
+		last = '0'
+
+		if len(literal) == 2 {
+			return "missing digits"
+		}
+	}
+
+	for ; i < len(literal); i++ {
+		ch := literal[i]
+
+		switch {
+		case ch == '_':
+			if last != '0' {
+				return "misplaced underscore"
+			}
+
+			last = '_'
+		case base != 10 && !isDigitInBase(rune(ch), base):
+			return fmt.Sprintf("invalid digit %q", ch)
+		case isDigit(rune(ch)) || base == 16 && isHexDigit(rune(ch)):
+			last = '0'
+		default:
+			if last == '_' {
+				return "misplaced underscore"
+			}
+
+			last = ch
+		}
+	}
+
+	if last == '_' {
+		return "misplaced underscore"
+	}
+
+	return ""
+}
+
+// readString reads a double-quoted string starting at the opening quote and
+// leaves the lexer on the closing quote. The token literal is the decoded value.
+func (l *Lexer) readString(pos token.Position) token.Token {
+	var out strings.Builder
+	valid := true
+	start := l.position
+
+	for {
+		l.readChar()
+
+		if l.ch == '"' {
+			break
+		}
+
+		if l.atEOF() {
+			l.addError(pos, "unterminated string literal")
+			return token.Token{Type: token.ILLEGAL, Literal: string(l.input[start:])}
+		}
+
+		if l.invalidRune() {
+			valid = false
+			continue
+		}
+
+		if l.ch != '\\' {
+			out.WriteRune(l.ch)
+			continue
+		}
+
+		escapePos := l.pos()
+		l.readChar()
+
+		switch l.ch {
+		case 'n':
+			out.WriteByte('\n')
+		case 't':
+			out.WriteByte('\t')
+		case '"':
+			out.WriteByte('"')
+		case '\\':
+			out.WriteByte('\\')
+		case 'u':
+			r, ok := l.readUnicodeEscape()
+
+			if !ok {
+				l.addError(escapePos, "invalid unicode escape sequence")
+				valid = false
+			}
+
+			out.WriteRune(r)
+		default:
+			if l.atEOF() {
+				continue
+			}
+
+			l.addError(escapePos, "unknown escape sequence \\%c", l.ch)
+			valid = false
+		}
+	}
+
+	if !valid {
+		return token.Token{Type: token.ILLEGAL, Literal: string(l.input[start:l.readPosition])}
+	}
+
+	return token.Token{Type: token.STRING, Literal: out.String()}
+}
+
+// readUnicodeEscape reads the "{XXXX}" part of a \u{XXXX} escape sequence and
+// leaves the lexer on the closing brace.
+func (l *Lexer) readUnicodeEscape() (rune, bool) {
+	if l.peekChar() != '{' {
+		return utf8.RuneError, false
+	}
+
+	l.readChar()
+	start := l.readPosition
+
+	for isHexDigit(l.peekChar()) {
+		l.readChar()
+	}
+
+	digits := string(l.input[start:l.readPosition])
+
+	if l.peekChar() != '}' || digits == "" {
+		return utf8.RuneError, false
+	}
+
+	l.readChar()
+	value, err := strconv.ParseUint(digits, 16, 32)
+
+	if err != nil || !utf8.ValidRune(rune(value)) {
+		return utf8.RuneError, false
+	}
+
+	return rune(value), true
+}
+
+func (l *Lexer) skipWhitespace() {
+	for {
+		switch {
+		case l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r':
+			l.readChar()
+		case l.mode&ScanComments == 0 && l.atCommentStart():
+			l.readComment(l.pos())
+			l.readChar()
+			l.discard()
+		default:
+			return
+		}
+	}
+}
+
+func (l *Lexer) atCommentStart() bool {
+	return l.ch == '/' && (l.peekChar() == '/' || l.peekChar() == '*')
+}
+
+// readComment reads a line or block comment and leaves the lexer on its last
+// character. Block comments nest, so /* a /* b */ c */ is a single comment.
+// Unless comments are scanned, their text is dropped while it is read and
+// the token has no literal.
+func (l *Lexer) readComment(pos token.Position) token.Token {
+	start := l.position
+
+	if l.peekChar() == '/' {
+		for l.peekChar() != '\n' && !l.peekAtEOF() {
+			l.readCommentChar()
+		}
+
+		return token.Token{Type: token.COMMENT, Literal: l.commentText(start, l.readPosition)}
+	}
+
+	l.readCommentChar()
+	depth := 1
+
+	for depth > 0 {
+		l.readCommentChar()
+
+		switch {
+		case l.atEOF():
+			l.addError(pos, "unterminated block comment")
+			return token.Token{Type: token.ILLEGAL, Literal: l.commentText(start, len(l.input))}
+		case l.ch == '/' && l.peekChar() == '*':
+			l.readCommentChar()
+			depth++
+		case l.ch == '*' && l.peekChar() == '/':
+			l.readCommentChar()
+			depth--
+		}
+	}
+
+	return token.Token{Type: token.COMMENT, Literal: l.commentText(start, l.readPosition)}
+}
+
+// readCommentChar advances to the next character of a comment. The text of
+// a skipped comment is dropped right away, so that it needn't fit into the
+// window.
+func (l *Lexer) readCommentChar() {
+	l.readChar()
+
+	if l.mode&ScanComments == 0 {
+		l.discard()
+	}
+}
+
+func (l *Lexer) commentText(start, end int) string {
+	if l.mode&ScanComments == 0 {
+		return ""
+	}
+
+	return string(l.input[start:end])
+}
+
+func (l *Lexer) atEllipsis() bool {
+	l.fill(2)
+	return bytes.HasPrefix(l.input[l.position:], []byte("..."))
+}
+
+func (l *Lexer) atEOF() bool {
+	return l.position >= len(l.input)
+}
+
+func (l *Lexer) peekAtEOF() bool {
+	l.fill(1)
+	return l.readPosition >= len(l.input)
+}
+
+func (l *Lexer) peekChar() rune {
+	l.fill(utf8.UTFMax)
+
 	if l.readPosition >= len(l.input) {
 		return 0
-	} else {
-		return l.input[l.readPosition]
 	}
+
+	ch, _ := utf8.DecodeRune(l.input[l.readPosition:])
+	return ch
 }
 
-func newToken(tokenType token.TokenType, ch byte) token.Token {
+func newToken(tokenType token.TokenType, ch rune) token.Token {
 	return token.Token{Type: tokenType, Literal: string(ch)}
 }
 
-func isLetter(ch byte) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+// newTwoCharToken consumes the peeked character and returns a token made of
+// the current and the peeked character.
+func (l *Lexer) newTwoCharToken(tokenType token.TokenType) token.Token {
+	ch := l.ch
+	l.readChar()
+	return token.Token{Type: tokenType, Literal: string(ch) + string(l.ch)}
+}
+
+// isLetter reports whether ch may start an identifier: any Unicode letter or
+// an underscore. After the first character, identifiers may also contain
+// Unicode decimal digits, so größe, π and x1 are all identifiers.
+func isLetter(ch rune) bool {
+	return unicode.IsLetter(ch) || ch == '_'
 }
 
-func isDigit(ch byte) bool {
+func isDigit(ch rune) bool {
 	return '0' <= ch && ch <= '9'
 }
+
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+func isAlphanumeric(ch rune) bool {
+	return isLetter(ch) || isDigit(ch)
+}
+
+func isBasePrefix(ch rune) bool {
+	switch ch {
+	case 'x', 'X', 'o', 'O', 'b', 'B':
+		return true
+	default:
+		return false
+	}
+}
+
+func isDigitInBase(ch rune, base int) bool {
+	switch base {
+	case 2:
+		return ch == '0' || ch == '1'
+	case 8:
+		return '0' <= ch && ch <= '7'
+	default:
+		return isHexDigit(ch)
+	}
+}
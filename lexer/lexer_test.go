@@ -1,7 +1,11 @@
 package lexer_test
 
 import (
+	"errors"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/henningrck/monkey-interpreter/lexer"
 	"github.com/henningrck/monkey-interpreter/token"
@@ -20,7 +24,7 @@ func TestNextToken(t *testing.T) {
 
 	let result = add(five, ten);
 
-	!-/*5;
+	!-/ *5;
 	5 < 10 > 5;
 
 	if (5 < 10) {
@@ -30,7 +34,19 @@ func TestNextToken(t *testing.T) {
 	}
 
 	10 == 10;
-	10 != 9;`
+	10 != 9;
+	"foobar"
+	"foo bar"
+	[1, 2];
+	{"foo": "bar"}
+	5 <= 10 >= 5 && true || false;
+	7 % 2 ** 3 & 1 | 2 ^ ~3 << 4 >> 5;
+	x += 1 -= 2 *= 3 /= 4;
+	while for in break continue
+	match (x) { _ => 1 }
+	throw try catch finally
+	[a, ...rest]
+	const`
 
 	tests := []struct {
 		expectedType    token.TokenType
@@ -117,6 +133,81 @@ func TestNextToken(t *testing.T) {
 		{token.NOT_EQ, "!="},
 		{token.INT, "9"},
 		{token.SEMICOLON, ";"},
+		{token.STRING, "foobar"},
+		{token.STRING, "foo bar"},
+		{token.LBRACKET, "["},
+		{token.INT, "1"},
+		{token.COMMA, ","},
+		{token.INT, "2"},
+		{token.RBRACKET, "]"},
+		{token.SEMICOLON, ";"},
+		{token.LBRACE, "{"},
+		{token.STRING, "foo"},
+		{token.COLON, ":"},
+		{token.STRING, "bar"},
+		{token.RBRACE, "}"},
+		{token.INT, "5"},
+		{token.LT_EQ, "<="},
+		{token.INT, "10"},
+		{token.GT_EQ, ">="},
+		{token.INT, "5"},
+		{token.AND, "&&"},
+		{token.TRUE, "true"},
+		{token.OR, "||"},
+		{token.FALSE, "false"},
+		{token.SEMICOLON, ";"},
+		{token.INT, "7"},
+		{token.PERCENT, "%"},
+		{token.INT, "2"},
+		{token.POWER, "**"},
+		{token.INT, "3"},
+		{token.BIT_AND, "&"},
+		{token.INT, "1"},
+		{token.BIT_OR, "|"},
+		{token.INT, "2"},
+		{token.BIT_XOR, "^"},
+		{token.BIT_NOT, "~"},
+		{token.INT, "3"},
+		{token.SHL, "<<"},
+		{token.INT, "4"},
+		{token.SHR, ">>"},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.PLUS_ASSIGN, "+="},
+		{token.INT, "1"},
+		{token.MINUS_ASSIGN, "-="},
+		{token.INT, "2"},
+		{token.ASTERISK_ASSIGN, "*="},
+		{token.INT, "3"},
+		{token.SLASH_ASSIGN, "/="},
+		{token.INT, "4"},
+		{token.SEMICOLON, ";"},
+		{token.WHILE, "while"},
+		{token.FOR, "for"},
+		{token.IN, "in"},
+		{token.BREAK, "break"},
+		{token.CONTINUE, "continue"},
+		{token.MATCH, "match"},
+		{token.LPAREN, "("},
+		{token.IDENT, "x"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.IDENT, "_"},
+		{token.ARROW, "=>"},
+		{token.INT, "1"},
+		{token.RBRACE, "}"},
+		{token.THROW, "throw"},
+		{token.TRY, "try"},
+		{token.CATCH, "catch"},
+		{token.FINALLY, "finally"},
+		{token.LBRACKET, "["},
+		{token.IDENT, "a"},
+		{token.COMMA, ","},
+		{token.ELLIPSIS, "..."},
+		{token.IDENT, "rest"},
+		{token.RBRACKET, "]"},
+		{token.CONST, "const"},
 		{token.EOF, ""},
 	}
 
@@ -128,3 +219,364 @@ func TestNextToken(t *testing.T) {
 		assert.Equal(t, test.expectedLiteral, tok.Literal)
 	}
 }
+
+func TestTokenPositions(t *testing.T) {
+	input := "let x = 5;\n  x + 10;\n\nfn"
+
+	tests := []struct {
+		expectedType token.TokenType
+		expectedPos  token.Position
+	}{
+		{token.LET, token.Position{Filename: "test.mk", Line: 1, Column: 1, Offset: 0}},
+		{token.IDENT, token.Position{Filename: "test.mk", Line: 1, Column: 5, Offset: 4}},
+		{token.ASSIGN, token.Position{Filename: "test.mk", Line: 1, Column: 7, Offset: 6}},
+		{token.INT, token.Position{Filename: "test.mk", Line: 1, Column: 9, Offset: 8}},
+		{token.SEMICOLON, token.Position{Filename: "test.mk", Line: 1, Column: 10, Offset: 9}},
+		{token.IDENT, token.Position{Filename: "test.mk", Line: 2, Column: 3, Offset: 13}},
+		{token.PLUS, token.Position{Filename: "test.mk", Line: 2, Column: 5, Offset: 15}},
+		{token.INT, token.Position{Filename: "test.mk", Line: 2, Column: 7, Offset: 17}},
+		{token.SEMICOLON, token.Position{Filename: "test.mk", Line: 2, Column: 9, Offset: 19}},
+		{token.FUNCTION, token.Position{Filename: "test.mk", Line: 4, Column: 1, Offset: 22}},
+		{token.EOF, token.Position{Filename: "test.mk", Line: 4, Column: 3, Offset: 24}},
+	}
+
+	l := lexer.NewWithFilename("test.mk", input)
+
+	for _, test := range tests {
+		tok := l.NextToken()
+		assert.Equal(t, test.expectedType, tok.Type)
+		assert.Equal(t, test.expectedPos, tok.Pos)
+	}
+}
+
+func TestStrings(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{`"foobar"`, token.STRING, "foobar"},
+		{`"foo bar"`, token.STRING, "foo bar"},
+		{`""`, token.STRING, ""},
+		{`"a\nb\tc"`, token.STRING, "a\nb\tc"},
+		{`"say \"hi\""`, token.STRING, `say "hi"`},
+		{`"back\\slash"`, token.STRING, `back\slash`},
+		{`"\u{48}\u{e9}\u{1F600}"`, token.STRING, "Hé😀"},
+		{`"bad \q escape"`, token.ILLEGAL, `"bad \q escape"`},
+		{`"bad \u{110000}"`, token.ILLEGAL, `"bad \u{110000}"`},
+		{`"unterminated`, token.ILLEGAL, `"unterminated`},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		tok := l.NextToken()
+		assert.Equal(t, test.expectedType, tok.Type, test.input)
+		assert.Equal(t, test.expectedLiteral, tok.Literal, test.input)
+		assert.Equal(t, token.TokenType(token.EOF), l.NextToken().Type, test.input)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{`let x = "abc";`, nil},
+		{`x @ y`, []string{"1:3: illegal character '@'"}},
+		{`a..b`, []string{"1:2: illegal character '.'", "1:3: illegal character '.'"}},
+		{`"a\qb"`, []string{`1:3: unknown escape sequence \q`}},
+		{`"a\u{zz}"`, []string{"1:3: invalid unicode escape sequence"}},
+		{"let s = \"abc", []string{"1:9: unterminated string literal"}},
+		{"let mask = 0b102;", []string{`1:12: invalid digit '2' in number literal "0b102"`}},
+		{"x + 0x;", []string{`1:5: missing digits in number literal "0x"`}},
+		{"\n  1__0", []string{`2:3: misplaced underscore in number literal "1__0"`}},
+		{"12ab", []string{`1:1: malformed number literal "12ab"`}},
+		{"let mode = 0755;", []string{`1:12: malformed number literal "0755"`}},
+		{"1e+x", []string{`1:1: malformed exponent in number literal "1e+"`}},
+		{`"abc\`, []string{"1:1: unterminated string literal"}},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+
+		for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		}
+
+		var messages []string
+
+		for _, err := range l.Errors() {
+			messages = append(messages, err.Error())
+		}
+
+		assert.Equal(t, test.expected, messages, test.input)
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := `// leading comment
+let x = 10 / 2; // trailing
+/* block
+   comment */ x /* a /* nested */ comment */ ;
+//`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+		expectedLine    int
+	}{
+		{token.COMMENT, "// leading comment", 1},
+		{token.LET, "let", 2},
+		{token.IDENT, "x", 2},
+		{token.ASSIGN, "=", 2},
+		{token.INT, "10", 2},
+		{token.SLASH, "/", 2},
+		{token.INT, "2", 2},
+		{token.SEMICOLON, ";", 2},
+		{token.COMMENT, "// trailing", 2},
+		{token.COMMENT, "/* block\n   comment */", 3},
+		{token.IDENT, "x", 4},
+		{token.COMMENT, "/* a /* nested */ comment */", 4},
+		{token.SEMICOLON, ";", 4},
+		{token.COMMENT, "//", 5},
+		{token.EOF, "", 5},
+	}
+
+	l := lexer.New(input)
+	l.SetMode(lexer.ScanComments)
+
+	for _, test := range tests {
+		tok := l.NextToken()
+		assert.Equal(t, test.expectedType, tok.Type)
+		assert.Equal(t, test.expectedLiteral, tok.Literal)
+		assert.Equal(t, test.expectedLine, tok.Pos.Line)
+	}
+
+	l = lexer.New(input)
+
+	for _, test := range tests {
+		if test.expectedType == token.COMMENT {
+			continue
+		}
+
+		tok := l.NextToken()
+		assert.Equal(t, test.expectedType, tok.Type)
+		assert.Equal(t, test.expectedLiteral, tok.Literal)
+	}
+
+	assert.Empty(t, l.Errors())
+}
+
+func TestUnterminatedBlockComment(t *testing.T) {
+	l := lexer.New("let x = 1; /* never /* closed */")
+
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+	}
+
+	if assert.Len(t, l.Errors(), 1) {
+		assert.Equal(t, "1:12: unterminated block comment", l.Errors()[0].Error())
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{"42", token.INT, "42"},
+		{"3.14", token.FLOAT, "3.14"},
+		{"0.5", token.FLOAT, "0.5"},
+		{"1e9", token.FLOAT, "1e9"},
+		{"1e-9", token.FLOAT, "1e-9"},
+		{"2.5E+3", token.FLOAT, "2.5E+3"},
+		{"1e", token.ILLEGAL, "1e"},
+		{"1e+", token.ILLEGAL, "1e+"},
+		{"0xFF", token.INT, "0xFF"},
+		{"0Xdead_beef", token.INT, "0Xdead_beef"},
+		{"0o755", token.INT, "0o755"},
+		{"0b1010", token.INT, "0b1010"},
+		{"0b_1010_1010", token.INT, "0b_1010_1010"},
+		{"1_000_000", token.INT, "1_000_000"},
+		{"1_000.000_1", token.FLOAT, "1_000.000_1"},
+		{"1e1_0", token.FLOAT, "1e1_0"},
+		{"0x", token.ILLEGAL, "0x"},
+		{"0b102", token.ILLEGAL, "0b102"},
+		{"0o8", token.ILLEGAL, "0o8"},
+		{"0xFG", token.ILLEGAL, "0xFG"},
+		{"1__000", token.ILLEGAL, "1__000"},
+		{"1000_", token.ILLEGAL, "1000_"},
+		{"1_.5", token.ILLEGAL, "1_.5"},
+		{"123abc", token.ILLEGAL, "123abc"},
+		{"0755", token.ILLEGAL, "0755"},
+		{"09", token.ILLEGAL, "09"},
+		{"0_1", token.ILLEGAL, "0_1"},
+		{"0", token.INT, "0"},
+		{"0.75", token.FLOAT, "0.75"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		tok := l.NextToken()
+		assert.Equal(t, test.expectedType, tok.Type, test.input)
+		assert.Equal(t, test.expectedLiteral, tok.Literal, test.input)
+		assert.Equal(t, token.TokenType(token.EOF), l.NextToken().Type, test.input)
+	}
+
+	l := lexer.New("1.foo")
+	assert.Equal(t, token.TokenType(token.INT), l.NextToken().Type)
+	assert.Equal(t, token.TokenType(token.ILLEGAL), l.NextToken().Type)
+	assert.Equal(t, token.TokenType(token.IDENT), l.NextToken().Type)
+}
+
+func TestUnicode(t *testing.T) {
+	input := "let größe = \"π ≈ 3.14\"; größe + ñ_1;\n  日本 /* ✓ */ x"
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+		expectedLine    int
+		expectedColumn  int
+		expectedOffset  int
+	}{
+		{token.LET, "let", 1, 1, 0},
+		{token.IDENT, "größe", 1, 5, 4},
+		{token.ASSIGN, "=", 1, 11, 12},
+		{token.STRING, "π ≈ 3.14", 1, 13, 14},
+		{token.SEMICOLON, ";", 1, 23, 27},
+		{token.IDENT, "größe", 1, 25, 29},
+		{token.PLUS, "+", 1, 31, 37},
+		{token.IDENT, "ñ_1", 1, 33, 39},
+		{token.SEMICOLON, ";", 1, 36, 43},
+		{token.IDENT, "日本", 2, 3, 47},
+		{token.IDENT, "x", 2, 14, 64},
+		{token.EOF, "", 2, 15, 65},
+	}
+
+	l := lexer.New(input)
+
+	for _, test := range tests {
+		tok := l.NextToken()
+		assert.Equal(t, test.expectedType, tok.Type)
+		assert.Equal(t, test.expectedLiteral, tok.Literal)
+		assert.Equal(t, test.expectedLine, tok.Pos.Line, test.expectedLiteral)
+		assert.Equal(t, test.expectedColumn, tok.Pos.Column, test.expectedLiteral)
+		assert.Equal(t, test.expectedOffset, tok.Pos.Offset, test.expectedLiteral)
+	}
+
+	assert.Empty(t, l.Errors())
+}
+
+func TestInvalidUTF8(t *testing.T) {
+	l := lexer.New("ab \xff cd \"x\xfey\" // \xfd")
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "ab"},
+		{token.ILLEGAL, "\xff"},
+		{token.IDENT, "cd"},
+		{token.ILLEGAL, "\"x\xfey\""},
+		{token.EOF, ""},
+	}
+
+	for _, test := range tests {
+		tok := l.NextToken()
+		assert.Equal(t, test.expectedType, tok.Type)
+		assert.Equal(t, test.expectedLiteral, tok.Literal)
+	}
+
+	var messages []string
+
+	for _, err := range l.Errors() {
+		messages = append(messages, err.Error())
+	}
+
+	assert.Equal(t, []string{
+		"1:4: invalid UTF-8 encoding",
+		"1:11: invalid UTF-8 encoding",
+		"1:18: invalid UTF-8 encoding",
+	}, messages)
+}
+
+func TestNewReader(t *testing.T) {
+	input := "let größe = \"π ≈ 3.14\\n\";\n" +
+		"/* block /* nested */ comment */ let x = 0x_FF + 1.5e3; // trailing\n" +
+		"if (größe != \"\") { [1, 2][0] } else { {\"k\": x} } @ \"open"
+
+	readers := map[string]func() io.Reader{
+		"full":     func() io.Reader { return strings.NewReader(input) },
+		"one byte": func() io.Reader { return iotest.OneByteReader(strings.NewReader(input)) },
+		"half":     func() io.Reader { return iotest.HalfReader(strings.NewReader(input)) },
+	}
+
+	for name, newReader := range readers {
+		for _, mode := range []lexer.Mode{0, lexer.ScanComments} {
+			expected := lexer.NewWithFilename("test.mk", input)
+			expected.SetMode(mode)
+			actual := lexer.NewReaderWithFilename("test.mk", newReader())
+			actual.SetMode(mode)
+
+			for {
+				want := expected.NextToken()
+				got := actual.NextToken()
+				assert.Equal(t, want, got, name)
+
+				if want.Type == token.EOF || got.Type == token.EOF {
+					break
+				}
+			}
+
+			assert.Equal(t, expected.Errors(), actual.Errors(), name)
+		}
+	}
+}
+
+func TestNewReaderLargeInput(t *testing.T) {
+	const count = 100000
+	input := strings.Repeat("let x = 12345;\n", count)
+
+	l := lexer.NewReader(strings.NewReader(input))
+	lets := 0
+	var last token.Token
+
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		if tok.Type == token.LET {
+			lets++
+		}
+
+		last = tok
+	}
+
+	assert.Equal(t, count, lets)
+	assert.Equal(t, token.Position{Line: count, Column: 14, Offset: len(input) - 2}, last.Pos)
+}
+
+func TestNewReaderLongTokens(t *testing.T) {
+	long := strings.Repeat("x", 1<<20)
+	input := "/* " + long + " */ \"" + long + "\" // " + long + "\n5"
+
+	l := lexer.NewReader(strings.NewReader(input))
+
+	str := l.NextToken()
+	assert.Equal(t, token.TokenType(token.STRING), str.Type)
+	assert.Equal(t, long, str.Literal)
+	assert.Equal(t, len(long)+7, str.Pos.Offset)
+
+	five := l.NextToken()
+	assert.Equal(t, token.Token{Type: token.INT, Literal: "5", Pos: token.Position{Line: 2, Column: 1, Offset: len(input) - 1}}, five)
+	assert.Equal(t, token.TokenType(token.EOF), l.NextToken().Type)
+	assert.Empty(t, l.Errors())
+}
+
+func TestNewReaderError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("let x = 1;"), iotest.ErrReader(errors.New("disk on fire")))
+	l := lexer.NewReader(r)
+
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+	}
+
+	if assert.Len(t, l.Errors(), 1) {
+		assert.Equal(t, "1:11: read error: disk on fire", l.Errors()[0].Error())
+	}
+}
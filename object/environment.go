@@ -0,0 +1,74 @@
+package object
+
+type Environment struct {
+	store  map[string]Object
+	consts map[string]bool
+	outer  *Environment
+}
+
+func NewEnvironment() *Environment {
+	return &Environment{store: make(map[string]Object)}
+}
+
+func NewEnclosedEnvironment(outer *Environment) *Environment {
+	env := NewEnvironment()
+	env.outer = outer
+	return env
+}
+
+func (e *Environment) Get(name string) (Object, bool) {
+	obj, ok := e.store[name]
+
+	if !ok && e.outer != nil {
+		obj, ok = e.outer.Get(name)
+	}
+
+	return obj, ok
+}
+
+func (e *Environment) Set(name string, val Object) Object {
+	e.store[name] = val
+	return val
+}
+
+// SetConst binds name like Set, but marks it as a constant. The environment
+// doesn't enforce this itself, see Declared and IsConst.
+func (e *Environment) SetConst(name string, val Object) Object {
+	if e.consts == nil {
+		e.consts = make(map[string]bool)
+	}
+
+	e.consts[name] = true
+	return e.Set(name, val)
+}
+
+// Declared reports whether name is bound in this scope itself, not counting
+// enclosing scopes, and whether it is bound as a constant.
+func (e *Environment) Declared(name string) (declared, constant bool) {
+	_, declared = e.store[name]
+	return declared, e.consts[name]
+}
+
+// IsConst reports whether name is a constant in the scope that defines it.
+func (e *Environment) IsConst(name string) bool {
+	for env := e; env != nil; env = env.outer {
+		if _, ok := env.store[name]; ok {
+			return env.consts[name]
+		}
+	}
+
+	return false
+}
+
+// Assign rebinds name in the scope that defines it. It reports false if no
+// enclosing scope defines name.
+func (e *Environment) Assign(name string, val Object) bool {
+	for env := e; env != nil; env = env.outer {
+		if _, ok := env.store[name]; ok {
+			env.store[name] = val
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,78 @@
+package object_test
+
+import (
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironment(t *testing.T) {
+	outer := object.NewEnvironment()
+	outer.Set("a", &object.Integer{Value: 1})
+	outer.Set("b", &object.Integer{Value: 2})
+
+	inner := object.NewEnclosedEnvironment(outer)
+	inner.Set("b", &object.Integer{Value: 3})
+
+	a, ok := inner.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", a.Inspect())
+
+	b, ok := inner.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, "3", b.Inspect())
+
+	b, ok = outer.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, "2", b.Inspect())
+
+	_, ok = outer.Get("c")
+	assert.False(t, ok)
+}
+
+func TestEnvironmentAssign(t *testing.T) {
+	outer := object.NewEnvironment()
+	outer.Set("a", &object.Integer{Value: 1})
+
+	inner := object.NewEnclosedEnvironment(outer)
+	inner.Set("b", &object.Integer{Value: 2})
+
+	assert.True(t, inner.Assign("a", &object.Integer{Value: 10}))
+	assert.True(t, inner.Assign("b", &object.Integer{Value: 20}))
+	assert.False(t, inner.Assign("c", &object.Integer{Value: 30}))
+
+	a, _ := outer.Get("a")
+	assert.Equal(t, "10", a.Inspect())
+
+	_, ok := outer.Get("b")
+	assert.False(t, ok)
+
+	_, ok = inner.Get("c")
+	assert.False(t, ok)
+}
+
+func TestEnvironmentConst(t *testing.T) {
+	outer := object.NewEnvironment()
+	outer.SetConst("pi", &object.Integer{Value: 3})
+	outer.Set("x", &object.Integer{Value: 1})
+
+	inner := object.NewEnclosedEnvironment(outer)
+	inner.Set("pi", &object.Integer{Value: 4})
+
+	declared, constant := outer.Declared("pi")
+	assert.True(t, declared)
+	assert.True(t, constant)
+
+	declared, constant = outer.Declared("x")
+	assert.True(t, declared)
+	assert.False(t, constant)
+
+	declared, _ = inner.Declared("x")
+	assert.False(t, declared)
+
+	assert.True(t, outer.IsConst("pi"))
+	assert.False(t, inner.IsConst("pi"))
+	assert.False(t, inner.IsConst("x"))
+	assert.False(t, inner.IsConst("y"))
+}
@@ -0,0 +1,114 @@
+package object_test
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/ast"
+	"github.com/henningrck/monkey-interpreter/object"
+	"github.com/henningrck/monkey-interpreter/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspect(t *testing.T) {
+	x := &ast.Identifier{Token: token.Token{Type: token.IDENT, Literal: "x"}, Value: "x"}
+
+	tests := []struct {
+		obj          object.Object
+		expectedType object.ObjectType
+		expected     string
+	}{
+		{&object.Integer{Value: -42}, object.INTEGER_OBJ, "-42"},
+		{&object.Float{Value: 3.25}, object.FLOAT_OBJ, "3.25"},
+		{&object.Float{Value: 2}, object.FLOAT_OBJ, "2.0"},
+		{&object.Float{Value: 1e-9}, object.FLOAT_OBJ, "1e-09"},
+		{object.TRUE, object.BOOLEAN_OBJ, "true"},
+		{object.FALSE, object.BOOLEAN_OBJ, "false"},
+		{object.NULL, object.NULL_OBJ, "null"},
+		{&object.ReturnValue{Value: &object.Integer{Value: 7}}, object.RETURN_VALUE_OBJ, "7"},
+		{&object.Error{Message: "type mismatch: INTEGER + BOOLEAN"}, object.ERROR_OBJ, "ERROR: type mismatch: INTEGER + BOOLEAN"},
+		{&object.Error{Message: "bad input", Kind: object.RUNTIME_ERROR}, object.ERROR_OBJ, "ERROR: bad input"},
+		{&object.Error{Message: "bad input", Kind: "ValueError"}, object.ERROR_OBJ, "ERROR: ValueError: bad input"},
+		{
+			&object.Function{
+				Parameters: []*ast.Identifier{x},
+				Body: &ast.BlockStatement{
+					Token: token.Token{Type: token.LBRACE, Literal: "{"},
+					Statements: []ast.Statement{
+						&ast.ExpressionStatement{Token: x.Token, Expression: x},
+					},
+				},
+			},
+			object.FUNCTION_OBJ,
+			"fn(x) {\nx\n}",
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expectedType, test.obj.Type())
+		assert.Equal(t, test.expected, test.obj.Inspect())
+	}
+}
+
+func TestStringHashKey(t *testing.T) {
+	hello1 := &object.String{Value: "Hello World"}
+	hello2 := &object.String{Value: "Hello World"}
+	diff1 := &object.String{Value: "My name is johnny"}
+	diff2 := &object.String{Value: "My name is johnny"}
+
+	assert.Equal(t, hello1.HashKey(), hello2.HashKey())
+	assert.Equal(t, diff1.HashKey(), diff2.HashKey())
+	assert.NotEqual(t, hello1.HashKey(), diff1.HashKey())
+
+	hash := object.NewHash()
+
+	for i := 0; i < 10000; i++ {
+		hash.Set(&object.String{Value: strconv.Itoa(i)}, object.NULL)
+	}
+
+	assert.Len(t, hash.Pairs, 10000)
+	assert.Equal(t, "Hello World", hello1.HashKey().Text)
+}
+
+func TestHashKeysDifferByType(t *testing.T) {
+	assert.NotEqual(t, (&object.Integer{Value: 1}).HashKey(), object.TRUE.HashKey())
+	assert.NotEqual(t, (&object.Integer{Value: 0}).HashKey(), object.FALSE.HashKey())
+}
+
+func TestBigIntegerHashKey(t *testing.T) {
+	two64 := new(big.Int).Lsh(big.NewInt(1), 64)
+	a := &object.BigInteger{Value: two64}
+	b := &object.BigInteger{Value: new(big.Int).Set(two64)}
+	negated := &object.BigInteger{Value: new(big.Int).Neg(two64)}
+
+	assert.Equal(t, a.HashKey(), b.HashKey())
+	assert.NotEqual(t, a.HashKey(), negated.HashKey())
+
+	hash := object.NewHash()
+	n := new(big.Int).Set(two64)
+
+	for i := 0; i < 10000; i++ {
+		hash.Set(&object.BigInteger{Value: new(big.Int).Set(n)}, object.NULL)
+		n.Add(n, big.NewInt(1))
+	}
+
+	assert.Len(t, hash.Pairs, 10000)
+}
+
+func TestHashInsertionOrder(t *testing.T) {
+	hash := object.NewHash()
+	hash.Set(&object.String{Value: "b"}, &object.Integer{Value: 1})
+	hash.Set(&object.Integer{Value: 3}, &object.Integer{Value: 2})
+	hash.Set(&object.String{Value: "a"}, &object.Integer{Value: 3})
+	hash.Set(&object.String{Value: "b"}, &object.Integer{Value: 4})
+
+	assert.Equal(t, "{b: 4, 3: 2, a: 3}", hash.Inspect())
+
+	value, ok := hash.Get(&object.Integer{Value: 3})
+	assert.True(t, ok)
+	assert.Equal(t, "2", value.Inspect())
+
+	_, ok = hash.Get(&object.String{Value: "c"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/henningrck/monkey-interpreter/token"
+)
+
+// ParseError describes a single syntax error. Expected is empty when the
+// parser did not wait for one particular token type.
+type ParseError struct {
+	Pos      token.Position
+	Expected token.TokenType
+	Actual   token.TokenType
+	Msg      string
+}
+
+// Error prefixes the message with the position, as in "line 1:9: msg", or
+// "main.mk:1:9: msg" when the source has a filename.
+func (e *ParseError) Error() string {
+	switch {
+	case !e.Pos.IsValid():
+		return e.Msg
+	case e.Pos.Filename != "":
+		return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+	default:
+		return fmt.Sprintf("line %s: %s", e.Pos, e.Msg)
+	}
+}
+
+// Render formats the error followed by the offending source line and a caret
+// pointing at the error column, which is counted in runes.
+func (e *ParseError) Render(source string) string {
+	var out strings.Builder
+	out.WriteString(e.Error())
+
+	line, ok := sourceLine(source, e.Pos.Line)
+
+	if !ok {
+		return out.String()
+	}
+
+	out.WriteString("\n\t")
+	out.WriteString(line)
+	out.WriteString("\n\t")
+
+	for i, ch := range []rune(line) {
+		if i >= e.Pos.Column-1 {
+			break
+		}
+
+		if ch == '\t' {
+			out.WriteByte('\t')
+		} else {
+			out.WriteByte(' ')
+		}
+	}
+
+	out.WriteString("^")
+	return out.String()
+}
+
+type ErrorList []*ParseError
+
+func (l ErrorList) Len() int      { return len(l) }
+func (l ErrorList) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
+
+func (l ErrorList) Less(i, j int) bool {
+	a, b := l[i].Pos, l[j].Pos
+
+	if a.Filename != b.Filename {
+		return a.Filename < b.Filename
+	}
+
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+
+	return a.Column < b.Column
+}
+
+func (l ErrorList) Sort() {
+	sort.Stable(l)
+}
+
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
+
+// Err returns nil for an empty list, so that callers don't end up with a
+// non-nil error interface holding no errors.
+func (l ErrorList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+
+	return l
+}
+
+func sourceLine(source string, line int) (string, bool) {
+	if line < 1 {
+		return "", false
+	}
+
+	lines := strings.Split(source, "\n")
+
+	if line > len(lines) {
+		return "", false
+	}
+
+	return strings.TrimRight(lines[line-1], "\r"), true
+}
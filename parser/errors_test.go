@@ -0,0 +1,210 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/lexer"
+	"github.com/henningrck/monkey-interpreter/parser"
+	"github.com/henningrck/monkey-interpreter/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseErrors(t *testing.T) {
+	input := "let x = 5;\nlet = 10;\nadd(1, 2;"
+
+	l := lexer.New(input)
+	p := parser.New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	assert.NotEmpty(t, errors)
+
+	first := errors[0]
+	assert.Equal(t, token.Position{Line: 2, Column: 5, Offset: 15}, first.Pos)
+	assert.Equal(t, token.TokenType(token.IDENT), first.Expected)
+	assert.Equal(t, token.TokenType(token.ASSIGN), first.Actual)
+	assert.Equal(t, "line 2:5: expected next token to be IDENT, got = instead", first.Error())
+
+	last := errors[len(errors)-1]
+	assert.Equal(t, 3, last.Pos.Line)
+	assert.Equal(t, token.TokenType(token.RPAREN), last.Expected)
+	assert.Equal(t, token.TokenType(token.SEMICOLON), last.Actual)
+}
+
+func TestParseErrorWithFilename(t *testing.T) {
+	l := lexer.NewWithFilename("f.mk", "let x = ;")
+	p := parser.New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+
+	if assert.Len(t, errors, 1) {
+		assert.Equal(t, "f.mk:1:9: no prefix parse function for ; found", errors[0].Error())
+	}
+}
+
+func TestParseErrorRender(t *testing.T) {
+	source := "let a = 1;\n\tadd(1, 2;"
+
+	l := lexer.New(source)
+	p := parser.New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	assert.Len(t, errors, 1)
+
+	expected := "line 2:10: expected next token to be ), got ; instead\n" +
+		"\t\tadd(1, 2;\n" +
+		"\t\t        ^"
+	assert.Equal(t, expected, errors[0].Render(source))
+}
+
+func TestParseErrorRenderUnicode(t *testing.T) {
+	source := `let größe = "ä" +;`
+
+	l := lexer.New(source)
+	p := parser.New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	assert.Len(t, errors, 1)
+
+	expected := "line 1:18: no prefix parse function for ; found\n" +
+		"\tlet größe = \"ä\" +;\n" +
+		"\t                 ^"
+	assert.Equal(t, expected, errors[0].Render(source))
+}
+
+func TestErrorListSort(t *testing.T) {
+	errors := parser.ErrorList{
+		{Pos: token.Position{Line: 3, Column: 1}, Msg: "c"},
+		{Pos: token.Position{Line: 1, Column: 7}, Msg: "b"},
+		{Pos: token.Position{Line: 1, Column: 2}, Msg: "a"},
+	}
+
+	errors.Sort()
+
+	assert.Equal(t, "a", errors[0].Msg)
+	assert.Equal(t, "b", errors[1].Msg)
+	assert.Equal(t, "c", errors[2].Msg)
+	assert.Equal(t, "line 1:2: a (and 2 more errors)", errors.Error())
+	assert.Nil(t, parser.ErrorList{}.Err())
+	assert.Error(t, errors.Err())
+}
+
+func TestErrorRecovery(t *testing.T) {
+	tests := []struct {
+		input              string
+		expectedErrors     []string
+		expectedStatements string
+	}{
+		{
+			"let = 5; let y = 10;",
+			[]string{"line 1:5: expected next token to be IDENT, got = instead"},
+			"let y = 10;",
+		},
+		{
+			"let x 5; let y = 10; y;",
+			[]string{"line 1:7: expected next token to be =, got INT instead"},
+			"let y = 10;y",
+		},
+		{
+			"add(1, 2; let a = 1; let b = ;",
+			[]string{
+				"line 1:9: expected next token to be ), got ; instead",
+				"line 1:30: no prefix parse function for ; found",
+			},
+			"let a = 1;",
+		},
+		{
+			"let f = fn(x) { let = x; x }; f(1);",
+			[]string{"line 1:21: expected next token to be IDENT, got = instead"},
+			"let f = fn(x) x;f(1)",
+		},
+		{
+			"if (x) { x",
+			[]string{"line 1:11: expected } to close block, got EOF instead"},
+			"",
+		},
+		{
+			"let s = \"abc\\q\"; let t = \"ok\";",
+			[]string{"line 1:13: unknown escape sequence \\q"},
+			"let t = \"ok\";",
+		},
+		{
+			"let let x = 1;",
+			[]string{"line 1:5: expected next token to be IDENT, got LET instead"},
+			"let x = 1;",
+		},
+		{
+			"let f = fn(x) { x + }; let g = 1",
+			[]string{"line 1:21: no prefix parse function for } found"},
+			"let f = fn(x) ;let g = 1;",
+		},
+		{
+			"if (x { 1 }; 5",
+			[]string{"line 1:7: expected next token to be ), got { instead"},
+			"5",
+		},
+		{
+			"let h = {\"a\" 1}; h",
+			[]string{"line 1:14: expected next token to be :, got INT instead"},
+			"h",
+		},
+		{
+			"let f = fn(x, x) { x }; f",
+			[]string{"line 1:15: duplicate parameter x"},
+			"f",
+		},
+		{
+			"for (1 in y) { 2 }; 3",
+			[]string{"line 1:6: expected next token to be IDENT, got INT instead"},
+			"3",
+		},
+		{
+			"let a = [1, 2; let b = 3",
+			[]string{"line 1:14: expected next token to be ], got ; instead"},
+			"let b = 3;",
+		},
+		{
+			"while (true) { f(x }; 4",
+			[]string{"line 1:20: expected next token to be ), got } instead"},
+			"while true 4",
+		},
+		{
+			"let @ = 1; let y = 2;",
+			[]string{"line 1:5: illegal character '@'"},
+			"let y = 2;",
+		},
+		{
+			"let [a, @] = [1, 2]; 3",
+			[]string{"line 1:9: illegal character '@'"},
+			"3",
+		},
+		{
+			"try { 1 } @; 2",
+			[]string{"line 1:11: illegal character '@'"},
+			"2",
+		},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+		program := p.ParseProgram()
+
+		messages := []string{}
+
+		for _, err := range p.Errors() {
+			messages = append(messages, err.Error())
+		}
+
+		assert.Equal(t, test.expectedErrors, messages, test.input)
+
+		for _, stmt := range program.Statements {
+			assert.NotNil(t, stmt)
+		}
+
+		assert.Equal(t, test.expectedStatements, program.String(), test.input)
+	}
+}
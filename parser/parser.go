@@ -1,7 +1,9 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
 
 	"github.com/henningrck/monkey-interpreter/ast"
@@ -12,24 +14,54 @@ import (
 const (
 	_ int = iota
 	LOWEST
+	ASSIGN
+	LOGICAL_OR
+	LOGICAL_AND
 	EQUALS
 	LESSGREATER
+	BITWISE_OR
+	BITWISE_XOR
+	BITWISE_AND
+	SHIFT
 	SUM
 	PRODUCT
 	PREFIX
+	POWER
 	CALL
+	INDEX
 )
 
 var precedences = map[token.TokenType]int{
-	token.EQ:       EQUALS,
-	token.NOT_EQ:   EQUALS,
-	token.LT:       LESSGREATER,
-	token.GT:       LESSGREATER,
-	token.PLUS:     SUM,
-	token.MINUS:    SUM,
-	token.SLASH:    PRODUCT,
-	token.ASTERISK: PRODUCT,
-	token.LPAREN:   CALL,
+	token.ASSIGN:          ASSIGN,
+	token.PLUS_ASSIGN:     ASSIGN,
+	token.MINUS_ASSIGN:    ASSIGN,
+	token.ASTERISK_ASSIGN: ASSIGN,
+	token.SLASH_ASSIGN:    ASSIGN,
+	token.EQ:              EQUALS,
+	token.NOT_EQ:          EQUALS,
+	token.OR:              LOGICAL_OR,
+	token.AND:             LOGICAL_AND,
+	token.LT:              LESSGREATER,
+	token.GT:              LESSGREATER,
+	token.LT_EQ:           LESSGREATER,
+	token.GT_EQ:           LESSGREATER,
+	token.PLUS:            SUM,
+	token.MINUS:           SUM,
+	token.SLASH:           PRODUCT,
+	token.ASTERISK:        PRODUCT,
+	token.PERCENT:         PRODUCT,
+	token.BIT_OR:          BITWISE_OR,
+	token.BIT_XOR:         BITWISE_XOR,
+	token.BIT_AND:         BITWISE_AND,
+	token.SHL:             SHIFT,
+	token.SHR:             SHIFT,
+	token.POWER:           POWER,
+	token.LPAREN:          CALL,
+	token.LBRACKET:        INDEX,
+}
+
+var rightAssociative = map[token.TokenType]bool{
+	token.POWER: true,
 }
 
 type (
@@ -37,44 +69,88 @@ type (
 	infixParseFn  func(ast.Expression) ast.Expression
 )
 
+// bailout is raised by the parser after recording an error. It unwinds to the
+// enclosing parseStatement, which skips ahead to a safe place to resume.
+type bailout struct{}
+
 type Parser struct {
-	l      *lexer.Lexer
-	errors []string
+	l        *lexer.Lexer
+	errors   ErrorList
+	warnings ErrorList
 
 	curToken  token.Token
 	peekToken token.Token
 
+	// loopDepth counts the loops enclosing the current statement within the
+	// innermost function, so break and continue can be checked statically.
+	loopDepth int
+
+	// open holds the brackets that are opened up to and including the
+	// current token but not closed yet. Error recovery uses it to skip a
+	// broken statement as a whole.
+	open []token.TokenType
+
+	// atBlockEnd is set when error recovery stopped on the brace closing the
+	// enclosing block, which the block still has to see.
+	atBlockEnd bool
+
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
 }
 
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{
-		l:      l,
-		errors: []string{},
+		l:        l,
+		errors:   ErrorList{},
+		warnings: ErrorList{},
 	}
 
 	p.prefixParseFns = make(map[token.TokenType]prefixParseFn)
 	p.registerPrefix(token.IDENT, p.parseIdentifier)
 	p.registerPrefix(token.INT, p.parseIntegerLiteral)
+	p.registerPrefix(token.FLOAT, p.parseFloatLiteral)
+	p.registerPrefix(token.STRING, p.parseStringLiteral)
 	p.registerPrefix(token.TRUE, p.parseBooleanLiteral)
 	p.registerPrefix(token.FALSE, p.parseBooleanLiteral)
 	p.registerPrefix(token.FUNCTION, p.parseFunctionLiteral)
 	p.registerPrefix(token.BANG, p.parsePrefixExpression)
 	p.registerPrefix(token.MINUS, p.parsePrefixExpression)
+	p.registerPrefix(token.BIT_NOT, p.parsePrefixExpression)
 	p.registerPrefix(token.LPAREN, p.parseGroupedExpression)
 	p.registerPrefix(token.IF, p.parseIfExpression)
+	p.registerPrefix(token.LBRACKET, p.parseArrayLiteral)
+	p.registerPrefix(token.LBRACE, p.parseHashLiteral)
+	p.registerPrefix(token.MATCH, p.parseMatchExpression)
+	p.registerPrefix(token.TRY, p.parseTryExpression)
+	p.registerPrefix(token.ILLEGAL, p.parseIllegal)
 
 	p.infixParseFns = make(map[token.TokenType]infixParseFn)
 	p.registerInfix(token.PLUS, p.parseInfixExpression)
 	p.registerInfix(token.MINUS, p.parseInfixExpression)
 	p.registerInfix(token.SLASH, p.parseInfixExpression)
 	p.registerInfix(token.ASTERISK, p.parseInfixExpression)
+	p.registerInfix(token.PERCENT, p.parseInfixExpression)
+	p.registerInfix(token.POWER, p.parseInfixExpression)
+	p.registerInfix(token.BIT_AND, p.parseInfixExpression)
+	p.registerInfix(token.BIT_OR, p.parseInfixExpression)
+	p.registerInfix(token.BIT_XOR, p.parseInfixExpression)
+	p.registerInfix(token.SHL, p.parseInfixExpression)
+	p.registerInfix(token.SHR, p.parseInfixExpression)
 	p.registerInfix(token.EQ, p.parseInfixExpression)
 	p.registerInfix(token.NOT_EQ, p.parseInfixExpression)
 	p.registerInfix(token.LT, p.parseInfixExpression)
 	p.registerInfix(token.GT, p.parseInfixExpression)
+	p.registerInfix(token.LT_EQ, p.parseInfixExpression)
+	p.registerInfix(token.GT_EQ, p.parseInfixExpression)
+	p.registerInfix(token.AND, p.parseInfixExpression)
+	p.registerInfix(token.OR, p.parseInfixExpression)
 	p.registerInfix(token.LPAREN, p.parseCallExpression)
+	p.registerInfix(token.LBRACKET, p.parseIndexExpression)
+	p.registerInfix(token.ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.PLUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.MINUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.ASTERISK_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.SLASH_ASSIGN, p.parseAssignExpression)
 
 	p.nextToken()
 	p.nextToken()
@@ -85,6 +161,54 @@ func New(l *lexer.Lexer) *Parser {
 func (p *Parser) nextToken() {
 	p.curToken = p.peekToken
 	p.peekToken = p.l.NextToken()
+
+	for p.peekToken.Type == token.COMMENT {
+		p.peekToken = p.l.NextToken()
+	}
+
+	p.trackBrackets()
+}
+
+// trackBrackets updates the open brackets for the current token. A closing
+// brace also closes the parentheses and brackets left open inside it, and so
+// does a semicolon, which can't appear inside them directly.
+func (p *Parser) trackBrackets() {
+	switch p.curToken.Type {
+	case token.LPAREN, token.LBRACKET, token.LBRACE:
+		p.open = append(p.open, p.curToken.Type)
+	case token.RPAREN:
+		p.closeBracket(token.LPAREN)
+	case token.RBRACKET:
+		p.closeBracket(token.LBRACKET)
+	case token.RBRACE:
+		for i := len(p.open) - 1; i >= 0; i-- {
+			if p.open[i] == token.LBRACE {
+				p.open = p.open[:i]
+				break
+			}
+		}
+	case token.SEMICOLON:
+		for len(p.open) > 0 && p.open[len(p.open)-1] != token.LBRACE {
+			p.open = p.open[:len(p.open)-1]
+		}
+	}
+}
+
+func (p *Parser) closeBracket(t token.TokenType) {
+	if n := len(p.open); n > 0 && p.open[n-1] == t {
+		p.open = p.open[:n-1]
+	}
+}
+
+// nextStatement moves past the last token of a statement, unless error
+// recovery already stopped on the brace closing the enclosing block.
+func (p *Parser) nextStatement() {
+	if p.atBlockEnd {
+		p.atBlockEnd = false
+		return
+	}
+
+	p.nextToken()
 }
 
 func (p *Parser) ParseProgram() *ast.Program {
@@ -92,20 +216,60 @@ func (p *Parser) ParseProgram() *ast.Program {
 	program.Statements = []ast.Statement{}
 
 	for p.curToken.Type != token.EOF {
-		stmt := p.parseStatement()
-		program.Statements = append(program.Statements, stmt)
-		p.nextToken()
+		if stmt := p.parseStatement(); stmt != nil {
+			program.Statements = append(program.Statements, stmt)
+		}
+
+		p.nextStatement()
 	}
 
+	for _, err := range p.l.Errors() {
+		p.errors = append(p.errors, &ParseError{Pos: err.Pos, Actual: token.ILLEGAL, Msg: err.Msg})
+	}
+
+	// The resolver needs a complete syntax tree, which a program with syntax
+	// errors doesn't have.
+	if len(p.errors) == 0 {
+		p.errors = append(p.errors, resolve(program)...)
+	}
+
+	p.errors.Sort()
+	p.warnings.Sort()
 	return program
 }
 
-func (p *Parser) parseStatement() ast.Statement {
+func (p *Parser) parseStatement() (stmt ast.Statement) {
+	depth := len(p.open)
+
 	switch p.curToken.Type {
-	case token.LET:
+	case token.LPAREN, token.LBRACKET, token.LBRACE:
+		depth--
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(bailout); !ok {
+				panic(r)
+			}
+
+			stmt = nil
+			p.synchronize(depth)
+		}
+	}()
+
+	switch p.curToken.Type {
+	case token.LET, token.CONST:
 		return p.parseLetStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.THROW:
+		return p.parseThrowStatement()
+	case token.WHILE:
+		return p.parseWhileStatement()
+	case token.FOR:
+		return p.parseForStatement()
+	case token.BREAK, token.CONTINUE:
+		return p.parseLoopControlStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -114,16 +278,16 @@ func (p *Parser) parseStatement() ast.Statement {
 func (p *Parser) parseLetStatement() *ast.LetStatement {
 	stmt := &ast.LetStatement{Token: p.curToken}
 
-	if !p.expectPeek(token.IDENT) {
-		return nil
-	}
-
-	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
-
-	if !p.expectPeek(token.ASSIGN) {
-		return nil
+	if p.peekTokenIs(token.LBRACKET) || p.peekTokenIs(token.LBRACE) {
+		p.nextToken()
+		stmt.Pattern = p.parsePattern()
+		p.checkBindingPattern(stmt.Pattern)
+	} else {
+		p.expectPeek(token.IDENT)
+		stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 	}
 
+	p.expectPeek(token.ASSIGN)
 	p.nextToken()
 	stmt.Value = p.parseExpression(LOWEST)
 
@@ -146,6 +310,84 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	return stmt
 }
 
+func (p *Parser) parseThrowStatement() *ast.ThrowStatement {
+	stmt := &ast.ThrowStatement{Token: p.curToken}
+	p.nextToken()
+	stmt.Value = p.parseExpression(LOWEST)
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseWhileStatement() *ast.WhileStatement {
+	stmt := &ast.WhileStatement{Token: p.curToken}
+
+	p.expectPeek(token.LPAREN)
+	p.nextToken()
+	stmt.Condition = p.parseExpression(LOWEST)
+
+	p.expectPeek(token.RPAREN)
+	p.expectPeek(token.LBRACE)
+	stmt.Body = p.parseLoopBody()
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseForStatement() *ast.ForStatement {
+	stmt := &ast.ForStatement{Token: p.curToken}
+
+	p.expectPeek(token.LPAREN)
+	p.expectPeek(token.IDENT)
+	stmt.Variable = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	p.expectPeek(token.IN)
+	p.nextToken()
+	stmt.Iterable = p.parseExpression(LOWEST)
+
+	p.expectPeek(token.RPAREN)
+	p.expectPeek(token.LBRACE)
+	stmt.Body = p.parseLoopBody()
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseLoopBody() *ast.BlockStatement {
+	p.loopDepth++
+	defer func() { p.loopDepth-- }()
+
+	return p.parseBlockStatement()
+}
+
+func (p *Parser) parseLoopControlStatement() ast.Statement {
+	tok := p.curToken
+
+	if p.loopDepth == 0 {
+		p.addError(tok, "", fmt.Sprintf("%s outside of loop", tok.Literal))
+		panic(bailout{})
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	if tok.Type == token.BREAK {
+		return &ast.BreakStatement{Token: tok}
+	}
+
+	return &ast.ContinueStatement{Token: tok}
+}
+
 func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 	stmt := &ast.ExpressionStatement{
 		Token:      p.curToken,
@@ -164,7 +406,6 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 
 	if prefix == nil {
 		p.noPrefixParseFnError(p.curToken.Type)
-		return nil
 	}
 
 	leftExp := prefix()
@@ -191,16 +432,46 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{Token: p.curToken}
 	value, err := strconv.ParseInt(p.curToken.Literal, 0, 64)
 
+	if errors.Is(err, strconv.ErrRange) {
+		if lit.Big, _ = new(big.Int).SetString(p.curToken.Literal, 0); lit.Big != nil {
+			return lit
+		}
+	}
+
 	if err != nil {
 		msg := fmt.Sprintf("could not parse %q as integer", p.curToken.Literal)
-		p.errors = append(p.errors, msg)
-		return nil
+		p.addError(p.curToken, "", msg)
+		panic(bailout{})
+	}
+
+	lit.Value = value
+	return lit
+}
+
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{Token: p.curToken}
+	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
+
+	if err != nil {
+		msg := fmt.Sprintf("could not parse %q as float", p.curToken.Literal)
+		p.addError(p.curToken, "", msg)
+		panic(bailout{})
 	}
 
 	lit.Value = value
 	return lit
 }
 
+func (p *Parser) parseStringLiteral() ast.Expression {
+	return &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
+}
+
+// parseIllegal skips an ILLEGAL token. The lexer has already reported why the
+// token is illegal, so no further error is recorded here.
+func (p *Parser) parseIllegal() ast.Expression {
+	panic(bailout{})
+}
+
 func (p *Parser) parseBooleanLiteral() ast.Expression {
 	return &ast.BooleanLiteral{Token: p.curToken, Value: p.curTokenIs(token.TRUE)}
 }
@@ -208,45 +479,77 @@ func (p *Parser) parseBooleanLiteral() ast.Expression {
 func (p *Parser) parseFunctionLiteral() ast.Expression {
 	lit := &ast.FunctionLiteral{Token: p.curToken}
 
-	if !p.expectPeek(token.LPAREN) {
-		return nil
-	}
+	// A loop around the function literal doesn't extend into its default
+	// values or its body.
+	loopDepth := p.loopDepth
+	p.loopDepth = 0
+	defer func() { p.loopDepth = loopDepth }()
 
-	lit.Parameters = p.parseFunctionParameters()
-
-	if !p.expectPeek(token.LBRACE) {
-		return nil
-	}
+	p.expectPeek(token.LPAREN)
+	p.parseFunctionParameters(lit)
 
+	p.expectPeek(token.LBRACE)
 	lit.Body = p.parseBlockStatement()
 	return lit
 }
 
-func (p *Parser) parseFunctionParameters() []*ast.Identifier {
-	identifiers := []*ast.Identifier{}
+// parseFunctionParameters fills in the parameters of lit. Parameters with a
+// default value have to come after those without one, and a rest parameter
+// has to come last.
+func (p *Parser) parseFunctionParameters(lit *ast.FunctionLiteral) {
+	lit.Parameters = []*ast.Identifier{}
+	lit.Defaults = []ast.Expression{}
+	seen := map[string]bool{}
+
+	declare := func() *ast.Identifier {
+		p.expectPeek(token.IDENT)
+		ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+		if seen[ident.Value] {
+			p.addError(p.curToken, "", fmt.Sprintf("duplicate parameter %s", ident.Value))
+			panic(bailout{})
+		}
+
+		seen[ident.Value] = true
+		return ident
+	}
 
 	if p.peekTokenIs(token.RPAREN) {
 		p.nextToken()
-		return identifiers
+		return
 	}
 
-	p.nextToken()
+	for {
+		if p.peekTokenIs(token.ELLIPSIS) {
+			p.nextToken()
+			lit.Rest = declare()
+			break
+		}
 
-	ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
-	identifiers = append(identifiers, ident)
+		ident := declare()
+		var value ast.Expression
+
+		if p.peekTokenIs(token.ASSIGN) {
+			p.nextToken()
+			p.nextToken()
+			value = p.parseExpression(LOWEST)
+		} else if len(lit.Defaults) > 0 && lit.Defaults[len(lit.Defaults)-1] != nil {
+			msg := fmt.Sprintf("parameter %s without default follows parameter with default", ident.Value)
+			p.addError(ident.Token, "", msg)
+			panic(bailout{})
+		}
 
-	for p.peekTokenIs(token.COMMA) {
-		p.nextToken()
-		p.nextToken()
-		ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
-		identifiers = append(identifiers, ident)
-	}
+		lit.Parameters = append(lit.Parameters, ident)
+		lit.Defaults = append(lit.Defaults, value)
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
 
-	if !p.expectPeek(token.RPAREN) {
-		return nil
+		p.nextToken()
 	}
 
-	return identifiers
+	p.expectPeek(token.RPAREN)
 }
 
 func (p *Parser) parsePrefixExpression() ast.Expression {
@@ -268,83 +571,256 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	}
 
 	precedence := p.curPrecedence()
+
+	// Parsing the right operand one level lower lets an operator of the same
+	// precedence bind to the right, so a ** b ** c is a ** (b ** c).
+	if rightAssociative[p.curToken.Type] {
+		precedence--
+	}
+
 	p.nextToken()
 	exp.Right = p.parseExpression(precedence)
 	return exp
 }
 
+func (p *Parser) parseAssignExpression(target ast.Expression) ast.Expression {
+	switch target.(type) {
+	case *ast.Identifier, *ast.IndexExpression:
+	default:
+		msg := fmt.Sprintf("cannot assign to %s", target.String())
+		p.addError(p.curToken, "", msg)
+		panic(bailout{})
+	}
+
+	exp := &ast.AssignExpression{
+		Token:    p.curToken,
+		Target:   target,
+		Operator: p.curToken.Literal,
+	}
+
+	// Assignment is right-associative, so a = b = c assigns c to both.
+	p.nextToken()
+	exp.Value = p.parseExpression(ASSIGN - 1)
+	return exp
+}
+
 func (p *Parser) parseGroupedExpression() ast.Expression {
 	p.nextToken()
 	exp := p.parseExpression(LOWEST)
 
-	if !p.expectPeek(token.RPAREN) {
-		return nil
-	}
-
+	p.expectPeek(token.RPAREN)
 	return exp
 }
 
 func (p *Parser) parseIfExpression() ast.Expression {
 	exp := &ast.IfExpression{Token: p.curToken}
 
-	if !p.expectPeek(token.LPAREN) {
-		return nil
+	p.expectPeek(token.LPAREN)
+	p.nextToken()
+	exp.Condition = p.parseExpression(LOWEST)
+
+	p.expectPeek(token.RPAREN)
+	p.expectPeek(token.LBRACE)
+	exp.Consequence = p.parseBlockStatement()
+
+	if p.peekTokenIs(token.ELSE) {
+		p.nextToken()
+
+		p.expectPeek(token.LBRACE)
+		exp.Alternative = p.parseBlockStatement()
 	}
 
+	return exp
+}
+
+func (p *Parser) parseMatchExpression() ast.Expression {
+	exp := &ast.MatchExpression{Token: p.curToken, Arms: []ast.MatchArm{}}
+
+	p.expectPeek(token.LPAREN)
 	p.nextToken()
-	exp.Condition = p.parseExpression(LOWEST)
+	exp.Subject = p.parseExpression(LOWEST)
+
+	p.expectPeek(token.RPAREN)
+	p.expectPeek(token.LBRACE)
+
+	var coverage matchCoverage
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		tok := p.curToken
+		pattern := p.parsePattern()
+
+		if !coverage.add(pattern) {
+			p.addWarning(tok, fmt.Sprintf("unreachable match arm %s", pattern))
+		}
 
-	if !p.expectPeek(token.RPAREN) {
-		return nil
+		p.expectPeek(token.ARROW)
+		p.nextToken()
+		body := p.parseExpression(LOWEST)
+
+		exp.Arms = append(exp.Arms, ast.MatchArm{Pattern: pattern, Body: body})
+
+		if !p.peekTokenIs(token.RBRACE) {
+			p.expectPeek(token.COMMA)
+		}
 	}
 
-	if !p.expectPeek(token.LBRACE) {
-		return nil
+	p.expectPeek(token.RBRACE)
+
+	if !coverage.exhaustive() {
+		p.addWarning(exp.Token, "match is not exhaustive, add a _ arm")
 	}
 
-	exp.Consequence = p.parseBlockStatement()
+	return exp
+}
 
-	if p.peekTokenIs(token.ELSE) {
-		p.nextToken()
+func (p *Parser) parseTryExpression() ast.Expression {
+	exp := &ast.TryExpression{Token: p.curToken}
 
-		if !p.expectPeek(token.LBRACE) {
-			return nil
+	p.expectPeek(token.LBRACE)
+	exp.Block = p.parseBlockStatement()
+
+	if !p.peekTokenIs(token.CATCH) && !p.peekTokenIs(token.FINALLY) {
+		if !p.peekTokenIs(token.ILLEGAL) {
+			msg := fmt.Sprintf("expected catch or finally after try block, got %s instead", p.peekToken.Type)
+			p.addError(p.peekToken, token.CATCH, msg)
 		}
 
-		exp.Alternative = p.parseBlockStatement()
+		panic(bailout{})
+	}
+
+	if p.peekTokenIs(token.CATCH) {
+		p.nextToken()
+		p.expectPeek(token.LPAREN)
+		p.expectPeek(token.IDENT)
+		exp.CatchParameter = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+		p.expectPeek(token.RPAREN)
+		p.expectPeek(token.LBRACE)
+		exp.Catch = p.parseBlockStatement()
+	}
+
+	if p.peekTokenIs(token.FINALLY) {
+		p.nextToken()
+		p.expectPeek(token.LBRACE)
+		exp.Finally = p.parseBlockStatement()
 	}
 
 	return exp
 }
 
+// parseCallExpression parses positional arguments followed by named ones. A
+// named argument is an identifier followed by a colon.
 func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
-	exp := &ast.CallExpression{Token: p.curToken, Function: function}
-	exp.Arguments = p.parseCallArguments()
+	exp := &ast.CallExpression{
+		Token:          p.curToken,
+		Function:       function,
+		Arguments:      []ast.Expression{},
+		NamedArguments: []ast.NamedArgument{},
+	}
+
+	seen := map[string]bool{}
+
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		return exp
+	}
+
+	for {
+		p.nextToken()
+
+		if p.curTokenIs(token.IDENT) && p.peekTokenIs(token.COLON) {
+			name := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+			if seen[name.Value] {
+				p.addError(p.curToken, "", fmt.Sprintf("duplicate named argument %s", name.Value))
+				panic(bailout{})
+			}
+
+			seen[name.Value] = true
+
+			p.nextToken()
+			p.nextToken()
+			value := p.parseExpression(LOWEST)
+			exp.NamedArguments = append(exp.NamedArguments, ast.NamedArgument{Name: name, Value: value})
+		} else if len(exp.NamedArguments) > 0 {
+			p.addError(p.curToken, "", "positional argument follows named argument")
+			panic(bailout{})
+		} else {
+			exp.Arguments = append(exp.Arguments, p.parseExpression(LOWEST))
+		}
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+
+		p.nextToken()
+	}
+
+	p.expectPeek(token.RPAREN)
 	return exp
 }
 
-func (p *Parser) parseCallArguments() []ast.Expression {
-	args := []ast.Expression{}
+func (p *Parser) parseArrayLiteral() ast.Expression {
+	array := &ast.ArrayLiteral{Token: p.curToken}
+	array.Elements = p.parseExpressionList(token.RBRACKET)
+	return array
+}
 
-	if p.peekTokenIs(token.RPAREN) {
+// parseHashLiteral is only reached for a brace in prefix position. Blocks are
+// always introduced by a keyword such as if or fn, which call
+// parseBlockStatement directly.
+func (p *Parser) parseHashLiteral() ast.Expression {
+	hash := &ast.HashLiteral{Token: p.curToken, Pairs: []ast.HashPair{}}
+
+	for !p.peekTokenIs(token.RBRACE) {
 		p.nextToken()
-		return args
+		key := p.parseExpression(LOWEST)
+
+		p.expectPeek(token.COLON)
+		p.nextToken()
+		value := p.parseExpression(LOWEST)
+
+		hash.Pairs = append(hash.Pairs, ast.HashPair{Key: key, Value: value})
+
+		if !p.peekTokenIs(token.RBRACE) {
+			p.expectPeek(token.COMMA)
+		}
 	}
 
+	p.expectPeek(token.RBRACE)
+	return hash
+}
+
+func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
+	exp := &ast.IndexExpression{Token: p.curToken, Left: left}
+
 	p.nextToken()
-	args = append(args, p.parseExpression(LOWEST))
+	exp.Index = p.parseExpression(LOWEST)
 
-	for p.peekTokenIs(token.COMMA) {
-		p.nextToken()
+	p.expectPeek(token.RBRACKET)
+	return exp
+}
+
+func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
+	list := []ast.Expression{}
+
+	if p.peekTokenIs(end) {
 		p.nextToken()
-		args = append(args, p.parseExpression(LOWEST))
+		return list
 	}
 
-	if !p.expectPeek(token.RPAREN) {
-		return nil
+	p.nextToken()
+	list = append(list, p.parseExpression(LOWEST))
+
+	for p.peekTokenIs(token.COMMA) {
+		p.nextToken()
+		p.nextToken()
+		list = append(list, p.parseExpression(LOWEST))
 	}
 
-	return args
+	p.expectPeek(end)
+	return list
 }
 
 func (p *Parser) parseBlockStatement() *ast.BlockStatement {
@@ -353,9 +829,17 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	p.nextToken()
 
 	for !p.curTokenIs(token.RBRACE) && !p.curTokenIs(token.EOF) {
-		stmt := p.parseStatement()
-		block.Statements = append(block.Statements, stmt)
-		p.nextToken()
+		if stmt := p.parseStatement(); stmt != nil {
+			block.Statements = append(block.Statements, stmt)
+		}
+
+		p.nextStatement()
+	}
+
+	if !p.curTokenIs(token.RBRACE) {
+		msg := fmt.Sprintf("expected %s to close block, got %s instead", token.RBRACE, p.curToken.Type)
+		p.addError(p.curToken, token.RBRACE, msg)
+		panic(bailout{})
 	}
 
 	return block
@@ -369,13 +853,39 @@ func (p *Parser) peekTokenIs(t token.TokenType) bool {
 	return p.peekToken.Type == t
 }
 
-func (p *Parser) expectPeek(t token.TokenType) bool {
-	if p.peekTokenIs(t) {
-		p.nextToken()
-		return true
-	} else {
+func (p *Parser) expectPeek(t token.TokenType) {
+	if !p.peekTokenIs(t) {
 		p.peekError(t)
-		return false
+		panic(bailout{})
+	}
+
+	p.nextToken()
+}
+
+// synchronize advances to the end of a broken statement that started with
+// depth brackets open. Brackets opened inside the statement are skipped up to
+// their closing counterpart. After that, the current token becomes a
+// semicolon, or the token right before a closing brace, a statement keyword
+// or the end of input. A brace closing the enclosing block is never skipped.
+func (p *Parser) synchronize(depth int) {
+	for !p.curTokenIs(token.EOF) {
+		if len(p.open) < depth {
+			p.atBlockEnd = true
+			return
+		}
+
+		if len(p.open) == depth {
+			if p.curTokenIs(token.SEMICOLON) {
+				return
+			}
+
+			switch p.peekToken.Type {
+			case token.RBRACE, token.LET, token.CONST, token.RETURN, token.THROW, token.WHILE, token.FOR, token.EOF:
+				return
+			}
+		}
+
+		p.nextToken()
 	}
 }
 
@@ -395,18 +905,43 @@ func (p *Parser) curPrecedence() int {
 	return LOWEST
 }
 
-func (p *Parser) Errors() []string {
+func (p *Parser) Errors() ErrorList {
 	return p.errors
 }
 
+// Warnings returns problems that don't stop the program from running, such as
+// match arms that can never be reached.
+func (p *Parser) Warnings() ErrorList {
+	return p.warnings
+}
+
+func (p *Parser) addError(tok token.Token, expected token.TokenType, msg string) {
+	p.errors = append(p.errors, &ParseError{
+		Pos:      tok.Pos,
+		Expected: expected,
+		Actual:   tok.Type,
+		Msg:      msg,
+	})
+}
+
+func (p *Parser) addWarning(tok token.Token, msg string) {
+	p.warnings = append(p.warnings, &ParseError{Pos: tok.Pos, Actual: tok.Type, Msg: msg})
+}
+
 func (p *Parser) peekError(t token.TokenType) {
+	// The lexer has already reported the ILLEGAL token.
+	if p.peekTokenIs(token.ILLEGAL) {
+		return
+	}
+
 	msg := fmt.Sprintf("expected next token to be %s, got %s instead", t, p.peekToken.Type)
-	p.errors = append(p.errors, msg)
+	p.addError(p.peekToken, t, msg)
 }
 
 func (p *Parser) noPrefixParseFnError(t token.TokenType) {
 	msg := fmt.Sprintf("no prefix parse function for %s found", t)
-	p.errors = append(p.errors, msg)
+	p.addError(p.curToken, "", msg)
+	panic(bailout{})
 }
 
 func (p *Parser) registerPrefix(tokenType token.TokenType, fn prefixParseFn) {
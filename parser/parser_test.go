@@ -36,6 +36,62 @@ func TestLetStatements(t *testing.T) {
 	}
 }
 
+func TestDestructuringLetStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, b] = arr;", "let [a, b] = arr;"},
+		{"let [a, _, ...rest] = arr;", "let [a, _, ...rest] = arr;"},
+		{"let [...all] = arr;", "let [...all] = arr;"},
+		{"let {name, age} = person;", `let {"name": name, "age": age} = person;`},
+		{`let {"first": f, 1: [x, y]} = h;`, `let {"first": f, 1: [x, y]} = h;`},
+		{"let [{id}, [p, q]] = data;", `let [{"id": id}, [p, q]] = data;`},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		assert.Len(t, program.Statements, 1)
+
+		stmt, ok := program.Statements[0].(*ast.LetStatement)
+
+		if assert.True(t, ok) {
+			assert.Nil(t, stmt.Name)
+			assert.NotNil(t, stmt.Pattern)
+		}
+
+		assert.Equal(t, test.expected, program.String())
+	}
+}
+
+func TestDestructuringLetErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, 1] = arr;", "line 1:9: literal pattern 1 is not allowed in a binding"},
+		{`let {"k": "v"} = h;`, `line 1:11: literal pattern "v" is not allowed in a binding`},
+		{"let [...rest, a] = arr;", "line 1:13: expected next token to be ], got , instead"},
+		{"let [a, ...1] = arr;", "line 1:12: expected next token to be IDENT, got INT instead"},
+		{"let [a, a] = arr;", "line 1:9: duplicate binding a in pattern"},
+		{"let {a, b: c} = h;", "line 1:9: hash pattern keys must be literals, got IDENT"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+		p.ParseProgram()
+
+		if assert.NotEmpty(t, p.Errors(), test.input) {
+			assert.Equal(t, test.expected, p.Errors()[0].Error())
+		}
+	}
+}
+
 func TestReturnStatements(t *testing.T) {
 	tests := []struct {
 		input         string
@@ -93,6 +149,80 @@ func TestIntegerLiteralExpressions(t *testing.T) {
 	checkLiteral(t, expStmt.Expression, 5)
 }
 
+func TestIntegerLiteralNotations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"0xFF", 255},
+		{"0Xff", 255},
+		{"0o755", 493},
+		{"0b1010", 10},
+		{"0b_1111_0000", 240},
+		{"1_000_000", 1000000},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		assert.Len(t, program.Statements, 1)
+
+		expStmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		assert.True(t, ok)
+
+		lit, ok := expStmt.Expression.(*ast.IntegerLiteral)
+		assert.True(t, ok)
+		assert.Equal(t, test.expected, lit.Value)
+	}
+}
+
+func TestBigIntegerLiteral(t *testing.T) {
+	l := lexer.New("9223372036854775808")
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	expStmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	assert.True(t, ok)
+
+	lit, ok := expStmt.Expression.(*ast.IntegerLiteral)
+	assert.True(t, ok)
+	assert.Equal(t, "9223372036854775808", lit.Big.String())
+	assert.Equal(t, "9223372036854775808", lit.String())
+}
+
+func TestFloatLiteralExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14;", 3.14},
+		{"1e-9;", 1e-9},
+		{"2.5E+3;", 2500},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		assert.Len(t, program.Statements, 1)
+
+		expStmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		assert.True(t, ok)
+
+		lit, ok := expStmt.Expression.(*ast.FloatLiteral)
+		assert.True(t, ok)
+		assert.Equal(t, test.expected, lit.Value)
+		assert.Equal(t, test.input[:len(test.input)-1], lit.String())
+	}
+}
+
 func TestBooleanLiteralExpressions(t *testing.T) {
 	input := `true;`
 
@@ -110,6 +240,25 @@ func TestBooleanLiteralExpressions(t *testing.T) {
 	checkLiteral(t, expStmt.Expression, true)
 }
 
+func TestStringLiteralExpressions(t *testing.T) {
+	input := `"hello \"world\"\n";`
+
+	l := lexer.New(input)
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	assert.Len(t, program.Statements, 1)
+
+	expStmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	assert.True(t, ok)
+
+	lit, ok := expStmt.Expression.(*ast.StringLiteral)
+	assert.True(t, ok)
+	assert.Equal(t, "hello \"world\"\n", lit.Value)
+	assert.Equal(t, input[:len(input)-1], lit.String())
+}
+
 func TestFunctionLiteralExpressions(t *testing.T) {
 	input := `fn(x, y) { x + y; }`
 
@@ -167,6 +316,65 @@ func TestFunctionLiteralParameters(t *testing.T) {
 	}
 }
 
+func TestFunctionLiteralDefaultAndRestParameters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn(x, y = 10) { x + y }", "fn(x, y = 10) (x + y)"},
+		{"fn(x = 1, y = x * 2) { y }", "fn(x = 1, y = (x * 2)) y"},
+		{"fn(first, ...others) { others }", "fn(first, ...others) others"},
+		{"fn(...all) { all }", "fn(...all) all"},
+		{"fn(a, b = 2, ...c) { c }", "fn(a, b = 2, ...c) c"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		assert.Equal(t, test.expected, program.String())
+	}
+
+	l := lexer.New("fn(a, b = 2, ...c) {}")
+	p := parser.New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	funcLit := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.FunctionLiteral)
+	assert.Len(t, funcLit.Parameters, 2)
+	assert.Len(t, funcLit.Defaults, 2)
+	assert.Nil(t, funcLit.Defaults[0])
+	checkLiteral(t, funcLit.Defaults[1], 2)
+	checkIdentifier(t, funcLit.Rest, "c")
+}
+
+func TestFunctionParameterErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn(1, x) {}", "line 1:4: expected next token to be IDENT, got INT instead"},
+		{"fn(x, y + 1) {}", "line 1:9: expected next token to be ), got + instead"},
+		{"fn(x, y, x) {}", "line 1:10: duplicate parameter x"},
+		{"fn(x, ...x) {}", "line 1:10: duplicate parameter x"},
+		{"fn(x = 1, y) {}", "line 1:11: parameter y without default follows parameter with default"},
+		{"fn(...xs, y) {}", "line 1:9: expected next token to be ), got , instead"},
+		{"fn(x,) {}", "line 1:6: expected next token to be IDENT, got ) instead"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+		p.ParseProgram()
+
+		if assert.NotEmpty(t, p.Errors(), test.input) {
+			assert.Equal(t, test.expected, p.Errors()[0].Error(), test.input)
+		}
+	}
+}
+
 func TestPrefixExpressions(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -177,6 +385,7 @@ func TestPrefixExpressions(t *testing.T) {
 		{"-15;", "-", 15},
 		{"!true;", "!", true},
 		{"!false;", "!", false},
+		{"~5;", "~", 5},
 	}
 
 	for _, test := range tests {
@@ -212,6 +421,17 @@ func TestInfixExpressions(t *testing.T) {
 		{"5 < 5;", 5, "<", 5},
 		{"5 == 5;", 5, "==", 5},
 		{"5 != 5;", 5, "!=", 5},
+		{"5 <= 5;", 5, "<=", 5},
+		{"5 >= 5;", 5, ">=", 5},
+		{"5 % 5;", 5, "%", 5},
+		{"5 ** 5;", 5, "**", 5},
+		{"5 & 5;", 5, "&", 5},
+		{"5 | 5;", 5, "|", 5},
+		{"5 ^ 5;", 5, "^", 5},
+		{"5 << 5;", 5, "<<", 5},
+		{"5 >> 5;", 5, ">>", 5},
+		{"true && false", true, "&&", false},
+		{"true || false", true, "||", false},
 		{"true == true", true, "==", true},
 		{"true != false", true, "!=", false},
 		{"false == false", false, "==", false},
@@ -305,6 +525,42 @@ func TestCallExpression(t *testing.T) {
 	checkInfixExpression(t, callExp.Arguments[2], 4, "+", 5)
 }
 
+func TestCallExpressionNamedArguments(t *testing.T) {
+	l := lexer.New("f(1, y: 2 * 3, z: x)")
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	assert.Equal(t, "f(1, y: (2 * 3), z: x)", program.String())
+
+	exp := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	assert.Len(t, exp.Arguments, 1)
+
+	if assert.Len(t, exp.NamedArguments, 2) {
+		checkIdentifier(t, exp.NamedArguments[0].Name, "y")
+		checkInfixExpression(t, exp.NamedArguments[0].Value, 2, "*", 3)
+		checkIdentifier(t, exp.NamedArguments[1].Name, "z")
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"f(y: 1, 2)", "line 1:9: positional argument follows named argument"},
+		{"f(y: 1, y: 2)", "line 1:9: duplicate named argument y"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+		p.ParseProgram()
+
+		if assert.NotEmpty(t, p.Errors(), test.input) {
+			assert.Equal(t, test.expected, p.Errors()[0].Error(), test.input)
+		}
+	}
+}
+
 func TestOperatorPrecedenceParsing(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -406,6 +662,418 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"add(a + b + c * d / f + g)",
 			"add((((a + b) + ((c * d) / f)) + g))",
 		},
+		{
+			"a * [1, 2, 3, 4][b * c] * d",
+			"((a * ([1, 2, 3, 4][(b * c)])) * d)",
+		},
+		{
+			"add(a * b[2], b[1], 2 * [1, 2][1])",
+			"add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))",
+		},
+		{
+			"f(x)[0]",
+			"(f(x)[0])",
+		},
+		{
+			"a <= b == c >= d",
+			"((a <= b) == (c >= d))",
+		},
+		{
+			"a && b || c && d",
+			"((a && b) || (c && d))",
+		},
+		{
+			"a == b && c != d || !e",
+			"(((a == b) && (c != d)) || (!e))",
+		},
+		{
+			"a || b || c",
+			"((a || b) || c)",
+		},
+		{
+			"a ** b ** c",
+			"(a ** (b ** c))",
+		},
+		{
+			"-a ** b",
+			"(-(a ** b))",
+		},
+		{
+			"a * b ** c % d",
+			"((a * (b ** c)) % d)",
+		},
+		{
+			"a ** -b",
+			"(a ** (-b))",
+		},
+		{
+			"a | b ^ c & d",
+			"(a | (b ^ (c & d)))",
+		},
+		{
+			"a & b << c + d",
+			"(a & (b << (c + d)))",
+		},
+		{
+			"a << b >> c",
+			"((a << b) >> c)",
+		},
+		{
+			"a & b == c | d",
+			"((a & b) == (c | d))",
+		},
+		{
+			"~a & b",
+			"((~a) & b)",
+		},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		assert.Equal(t, test.expected, program.String())
+	}
+}
+
+func TestArrayLiteralExpressions(t *testing.T) {
+	input := "[1, 2 * 2, 3 + 3]"
+
+	l := lexer.New(input)
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	assert.Len(t, program.Statements, 1)
+
+	expStmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	assert.True(t, ok)
+
+	array, ok := expStmt.Expression.(*ast.ArrayLiteral)
+	assert.True(t, ok)
+	assert.Len(t, array.Elements, 3)
+	checkLiteral(t, array.Elements[0], 1)
+	checkInfixExpression(t, array.Elements[1], 2, "*", 2)
+	checkInfixExpression(t, array.Elements[2], 3, "+", 3)
+}
+
+func TestEmptyArrayLiteralExpressions(t *testing.T) {
+	l := lexer.New("[]")
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	expStmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	assert.True(t, ok)
+
+	array, ok := expStmt.Expression.(*ast.ArrayLiteral)
+	assert.True(t, ok)
+	assert.Len(t, array.Elements, 0)
+}
+
+func TestIndexExpressions(t *testing.T) {
+	input := "myArray[1 + 1]"
+
+	l := lexer.New(input)
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	expStmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	assert.True(t, ok)
+
+	indexExp, ok := expStmt.Expression.(*ast.IndexExpression)
+	assert.True(t, ok)
+	checkLiteral(t, indexExp.Left, "myArray")
+	checkInfixExpression(t, indexExp.Index, 1, "+", 1)
+}
+
+func TestHashLiteralExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{}`, `{}`},
+		{`{"one": 1, "two": 2, "three": 3}`, `{"one": 1, "two": 2, "three": 3}`},
+		{`{1: true, true: "x", "k": [1]}`, `{1: true, true: "x", "k": [1]}`},
+		{`{"one": 0 + 1, "two": 10 - 8}`, `{"one": (0 + 1), "two": (10 - 8)}`},
+		{`if (x) { {"a": 1} }`, `if x {"a": 1}`},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		assert.Len(t, program.Statements, 1)
+		assert.Equal(t, test.expected, program.String())
+	}
+}
+
+func TestHashLiteralPairs(t *testing.T) {
+	input := `{"one": 1, "two": 2, "three": 3}`
+
+	l := lexer.New(input)
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	expStmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	assert.True(t, ok)
+
+	hash, ok := expStmt.Expression.(*ast.HashLiteral)
+	assert.True(t, ok)
+	assert.Len(t, hash.Pairs, 3)
+
+	for i, key := range []string{"one", "two", "three"} {
+		lit, ok := hash.Pairs[i].Key.(*ast.StringLiteral)
+		assert.True(t, ok)
+		assert.Equal(t, key, lit.Value)
+		checkLiteral(t, hash.Pairs[i].Value, i+1)
+	}
+}
+
+func TestAssignExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x = 5;", "(x = 5)"},
+		{"x = y = 1 + 2;", "(x = (y = (1 + 2)))"},
+		{"x += y * 2;", "(x += (y * 2))"},
+		{"x -= 1; x *= 2; x /= 3;", "(x -= 1)(x *= 2)(x /= 3)"},
+		{"arr[0] = 1;", "((arr[0]) = 1)"},
+		{`h["k"] += v || w;`, `((h["k"]) += (v || w))`},
+		{"let f = fn() { x = x + 1 };", "let f = fn() (x = (x + 1));"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		assert.Equal(t, test.expected, program.String())
+	}
+}
+
+func TestInvalidAssignTargets(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"5 = x;", "line 1:3: cannot assign to 5"},
+		{"f() = 1;", "line 1:5: cannot assign to f()"},
+		{"a + b = c;", "line 1:7: cannot assign to (a + b)"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+		p.ParseProgram()
+
+		if assert.Len(t, p.Errors(), 1) {
+			assert.Equal(t, test.expected, p.Errors()[0].Error())
+		}
+	}
+}
+
+func TestWhileStatement(t *testing.T) {
+	input := "while (x < 10) { x += 1; if (x == 5) { break; } continue }"
+
+	l := lexer.New(input)
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	assert.Len(t, program.Statements, 1)
+
+	stmt, ok := program.Statements[0].(*ast.WhileStatement)
+	assert.True(t, ok)
+	checkInfixExpression(t, stmt.Condition, "x", "<", 10)
+	assert.Len(t, stmt.Body.Statements, 3)
+
+	_, ok = stmt.Body.Statements[2].(*ast.ContinueStatement)
+	assert.True(t, ok)
+	assert.Equal(t, "while (x < 10) (x += 1)if (x == 5) break;continue;", program.String())
+}
+
+func TestForStatement(t *testing.T) {
+	input := "for (item in [1, 2]) { puts(item); }; 5"
+
+	l := lexer.New(input)
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	assert.Len(t, program.Statements, 2)
+
+	stmt, ok := program.Statements[0].(*ast.ForStatement)
+	assert.True(t, ok)
+	checkIdentifier(t, stmt.Variable, "item")
+	assert.Equal(t, "[1, 2]", stmt.Iterable.String())
+	assert.Len(t, stmt.Body.Statements, 1)
+}
+
+func TestLoopControlOutsideLoop(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"break;", []string{"line 1:1: break outside of loop"}},
+		{"if (true) { continue }", []string{"line 1:13: continue outside of loop"}},
+		{
+			"while (true) { let f = fn() { break }; break }",
+			[]string{"line 1:31: break outside of loop"},
+		},
+		{
+			"while (true) { fn(x = if (true) { break }) { x } }",
+			[]string{"line 1:35: break outside of loop"},
+		},
+		{"for (x in y) { fn() { 1 }; continue }", nil},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+		p.ParseProgram()
+
+		messages := []string{}
+
+		for _, err := range p.Errors() {
+			messages = append(messages, err.Error())
+		}
+
+		assert.ElementsMatch(t, test.expected, messages, test.input)
+	}
+}
+
+func TestMatchExpression(t *testing.T) {
+	input := `match (x) {
+		1 => "one",
+		-2.5 => "negative",
+		[a, _] => a,
+		{"k": [v], 2: true} => v,
+		_ => "other",
+	}`
+
+	l := lexer.New(input)
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	assert.Empty(t, p.Warnings())
+	assert.Len(t, program.Statements, 1)
+
+	expStmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	assert.True(t, ok)
+
+	exp, ok := expStmt.Expression.(*ast.MatchExpression)
+	assert.True(t, ok)
+	checkIdentifier(t, exp.Subject, "x")
+	assert.Len(t, exp.Arms, 5)
+
+	_, ok = exp.Arms[0].Pattern.(*ast.LiteralPattern)
+	assert.True(t, ok)
+	_, ok = exp.Arms[2].Pattern.(*ast.ArrayPattern)
+	assert.True(t, ok)
+	_, ok = exp.Arms[3].Pattern.(*ast.HashPattern)
+	assert.True(t, ok)
+	_, ok = exp.Arms[4].Pattern.(*ast.WildcardPattern)
+	assert.True(t, ok)
+
+	expected := `match x {1 => "one", (-2.5) => "negative", [a, _] => a, {"k": [v], 2: true} => v, _ => "other"}`
+	assert.Equal(t, expected, program.String())
+}
+
+func TestMatchPatternErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"match (x) { a + 1 => 1 }", "line 1:15: expected next token to be =>, got + instead"},
+		{"match (x) { fn => 1 }", "line 1:13: unexpected FUNCTION in pattern"},
+		{"match (x) { [a, a] => 1 }", "line 1:17: duplicate binding a in pattern"},
+		{"match (x) { {k: 1} => 1 }", "line 1:14: hash pattern keys must be literals, got IDENT"},
+		{"match (x) { 1 2 }", "line 1:15: expected next token to be =>, got INT instead"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+		p.ParseProgram()
+
+		if assert.NotEmpty(t, p.Errors(), test.input) {
+			assert.Equal(t, test.expected, p.Errors()[0].Error())
+		}
+	}
+}
+
+func TestMatchWarnings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"match (x) { 1 => 1, _ => 2 }", []string{}},
+		{"match (x) { true => 1, false => 2 }", []string{}},
+		{"match (x) { n => n }", []string{}},
+		{"match (x) { 1 => 1 }", []string{"line 1:1: match is not exhaustive, add a _ arm"}},
+		{"match (x) { }", []string{"line 1:1: match is not exhaustive, add a _ arm"}},
+		{"match (x) { [a] => a, {} => 1 }", []string{"line 1:1: match is not exhaustive, add a _ arm"}},
+		{"match (x) { _ => 1, 2 => 2 }", []string{"line 1:21: unreachable match arm 2"}},
+		{
+			"match (x) { n => 1, _ => 2, [a] => 3 }",
+			[]string{"line 1:21: unreachable match arm _", "line 1:29: unreachable match arm [a]"},
+		},
+		{
+			`match (x) { "a" => 1, "a" => 2 }`,
+			[]string{"line 1:1: match is not exhaustive, add a _ arm", `line 1:23: unreachable match arm "a"`},
+		},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+		p.ParseProgram()
+		checkParserErrors(t, p)
+
+		messages := []string{}
+
+		for _, warning := range p.Warnings() {
+			messages = append(messages, warning.Error())
+		}
+
+		assert.Equal(t, test.expected, messages, test.input)
+	}
+}
+
+func TestThrowStatement(t *testing.T) {
+	l := lexer.New(`throw "bad" + x; 1`)
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	assert.Len(t, program.Statements, 2)
+
+	stmt, ok := program.Statements[0].(*ast.ThrowStatement)
+	assert.True(t, ok)
+	assert.Equal(t, `("bad" + x)`, stmt.Value.String())
+}
+
+func TestTryExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"try { f() } catch (e) { e }", "try f() catch e e"},
+		{"try { f() } finally { g() }", "try f() finally g()"},
+		{"let x = try { f() } catch (err) { 0 } finally { g() };", "let x = try f() catch err 0 finally g();"},
 	}
 
 	for _, test := range tests {
@@ -418,6 +1086,41 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 	}
 }
 
+func TestTryExpressionErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"try { f() }", "line 1:12: expected catch or finally after try block, got EOF instead"},
+		{"try { f() } catch { 1 }", "line 1:19: expected next token to be (, got { instead"},
+		{"try { f() } catch (1) { 1 }", "line 1:20: expected next token to be IDENT, got INT instead"},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+		p.ParseProgram()
+
+		if assert.NotEmpty(t, p.Errors(), test.input) {
+			assert.Equal(t, test.expected, p.Errors()[0].Error())
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := `// add two numbers
+	let add = fn(a, b) { a + b /* sum */ };
+	add(1, 2) // call`
+
+	l := lexer.New(input)
+	l.SetMode(lexer.ScanComments)
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	assert.Equal(t, "let add = fn(a, b) (a + b);add(1, 2)", program.String())
+}
+
 func checkParserErrors(t *testing.T, p *parser.Parser) {
 	errors := p.Errors()
 	assert.Len(t, errors, 0)
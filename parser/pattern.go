@@ -0,0 +1,196 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/henningrck/monkey-interpreter/ast"
+	"github.com/henningrck/monkey-interpreter/token"
+)
+
+// parsePattern parses the pattern starting at the current token and rejects
+// patterns that bind the same name twice.
+func (p *Parser) parsePattern() ast.Pattern {
+	pattern := p.parsePatternElement()
+	seen := map[string]bool{}
+
+	for _, ident := range ast.PatternBindings(pattern) {
+		if seen[ident.Value] {
+			msg := fmt.Sprintf("duplicate binding %s in pattern", ident.Value)
+			p.addError(ident.Token, "", msg)
+			panic(bailout{})
+		}
+
+		seen[ident.Value] = true
+	}
+
+	return pattern
+}
+
+func (p *Parser) parsePatternElement() ast.Pattern {
+	switch p.curToken.Type {
+	case token.IDENT:
+		if p.curToken.Literal == "_" {
+			return &ast.WildcardPattern{Token: p.curToken}
+		}
+
+		ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		return &ast.BindingPattern{Token: p.curToken, Name: ident}
+	case token.INT, token.FLOAT, token.STRING, token.TRUE, token.FALSE:
+		return &ast.LiteralPattern{Token: p.curToken, Value: p.parseLiteral()}
+	case token.MINUS:
+		if p.peekTokenIs(token.INT) || p.peekTokenIs(token.FLOAT) {
+			pattern := &ast.LiteralPattern{Token: p.curToken}
+			exp := &ast.PrefixExpression{Token: p.curToken, Operator: p.curToken.Literal}
+
+			p.nextToken()
+			exp.Right = p.parseLiteral()
+			pattern.Value = exp
+			return pattern
+		}
+	case token.LBRACKET:
+		return p.parseArrayPattern()
+	case token.LBRACE:
+		return p.parseHashPattern()
+	}
+
+	if !p.curTokenIs(token.ILLEGAL) {
+		msg := fmt.Sprintf("unexpected %s in pattern", p.curToken.Type)
+		p.addError(p.curToken, "", msg)
+	}
+
+	panic(bailout{})
+}
+
+func (p *Parser) parseArrayPattern() ast.Pattern {
+	pattern := &ast.ArrayPattern{Token: p.curToken, Elements: []ast.Pattern{}}
+
+	for !p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+
+		// A rest element has to come last.
+		if p.curTokenIs(token.ELLIPSIS) {
+			p.expectPeek(token.IDENT)
+			pattern.Rest = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			break
+		}
+
+		pattern.Elements = append(pattern.Elements, p.parsePatternElement())
+
+		if !p.peekTokenIs(token.RBRACKET) {
+			p.expectPeek(token.COMMA)
+		}
+	}
+
+	p.expectPeek(token.RBRACKET)
+	return pattern
+}
+
+func (p *Parser) parseHashPattern() ast.Pattern {
+	pattern := &ast.HashPattern{Token: p.curToken, Pairs: []ast.HashPatternPair{}}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+
+		// {name} is short for {"name": name}.
+		if p.curTokenIs(token.IDENT) && !p.peekTokenIs(token.COLON) {
+			key := &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
+			ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			value := &ast.BindingPattern{Token: p.curToken, Name: ident}
+			pattern.Pairs = append(pattern.Pairs, ast.HashPatternPair{Key: key, Value: value})
+
+			if !p.peekTokenIs(token.RBRACE) {
+				p.expectPeek(token.COMMA)
+			}
+
+			continue
+		}
+
+		switch p.curToken.Type {
+		case token.STRING, token.INT, token.TRUE, token.FALSE:
+		default:
+			msg := fmt.Sprintf("hash pattern keys must be literals, got %s", p.curToken.Type)
+			p.addError(p.curToken, "", msg)
+			panic(bailout{})
+		}
+
+		key := p.parseLiteral()
+
+		p.expectPeek(token.COLON)
+		p.nextToken()
+		value := p.parsePatternElement()
+
+		pattern.Pairs = append(pattern.Pairs, ast.HashPatternPair{Key: key, Value: value})
+
+		if !p.peekTokenIs(token.RBRACE) {
+			p.expectPeek(token.COMMA)
+		}
+	}
+
+	p.expectPeek(token.RBRACE)
+	return pattern
+}
+
+// checkBindingPattern rejects literal patterns, which a let binding can't
+// fall back from if they don't match.
+func (p *Parser) checkBindingPattern(pattern ast.Pattern) {
+	switch pattern := pattern.(type) {
+	case *ast.LiteralPattern:
+		msg := fmt.Sprintf("literal pattern %s is not allowed in a binding", pattern)
+		p.addError(pattern.Token, "", msg)
+		panic(bailout{})
+	case *ast.ArrayPattern:
+		for _, el := range pattern.Elements {
+			p.checkBindingPattern(el)
+		}
+	case *ast.HashPattern:
+		for _, pair := range pattern.Pairs {
+			p.checkBindingPattern(pair.Value)
+		}
+	}
+}
+
+// parseLiteral parses the literal at the current token on its own, without
+// treating any following operator as part of an expression.
+func (p *Parser) parseLiteral() ast.Expression {
+	return p.prefixParseFns[p.curToken.Type]()
+}
+
+// matchCoverage tracks which values the arms of a match expression handle so
+// far. It only knows about top-level patterns: an arm is unreachable if it
+// follows a catch-all arm or repeats a literal, and a match is exhaustive if
+// it has a catch-all arm or covers both booleans.
+type matchCoverage struct {
+	catchAll bool
+	literals map[string]bool
+}
+
+// add records the pattern of the next arm and reports whether that arm can
+// still be reached.
+func (c *matchCoverage) add(pattern ast.Pattern) bool {
+	if c.catchAll {
+		return false
+	}
+
+	switch pattern := pattern.(type) {
+	case *ast.WildcardPattern, *ast.BindingPattern:
+		c.catchAll = true
+	case *ast.LiteralPattern:
+		if c.literals == nil {
+			c.literals = map[string]bool{}
+		}
+
+		key := pattern.String()
+
+		if c.literals[key] {
+			return false
+		}
+
+		c.literals[key] = true
+	}
+
+	return true
+}
+
+func (c *matchCoverage) exhaustive() bool {
+	return c.catchAll || (c.literals["true"] && c.literals["false"])
+}
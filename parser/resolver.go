@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/henningrck/monkey-interpreter/ast"
+)
+
+// resolver checks constants statically, so that most assignments to a
+// constant and redeclarations of one are reported before the program runs.
+// It only knows the names declared in the program itself; anything else,
+// like names left over from earlier REPL input, is checked by the evaluator
+// at runtime.
+//
+// Its scopes mirror the environments of the evaluator: function bodies, loop
+// iterations, match arms and catch blocks get a scope of their own, while the
+// blocks of if and try share the scope around them.
+type resolver struct {
+	scopes []map[string]bool
+	errors ErrorList
+}
+
+func resolve(program *ast.Program) ErrorList {
+	r := &resolver{}
+	r.push()
+
+	for _, stmt := range program.Statements {
+		r.resolve(stmt)
+	}
+
+	return r.errors
+}
+
+func (r *resolver) push() {
+	r.scopes = append(r.scopes, map[string]bool{})
+}
+
+func (r *resolver) pop() {
+	r.scopes = r.scopes[:len(r.scopes)-1]
+}
+
+// declare records name in the innermost scope, which maps each name to
+// whether it is a constant.
+func (r *resolver) declare(name *ast.Identifier, constant bool) {
+	scope := r.scopes[len(r.scopes)-1]
+	wasConstant, declared := scope[name.Value]
+
+	switch {
+	case declared && wasConstant:
+		r.addError(name, fmt.Sprintf("cannot redeclare constant %s", name.Value))
+	case declared && constant:
+		r.addError(name, fmt.Sprintf("cannot redeclare %s as a constant", name.Value))
+	}
+
+	scope[name.Value] = constant || wasConstant
+}
+
+func (r *resolver) isConst(name string) bool {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if constant, ok := r.scopes[i][name]; ok {
+			return constant
+		}
+	}
+
+	return false
+}
+
+func (r *resolver) addError(ident *ast.Identifier, msg string) {
+	r.errors = append(r.errors, &ParseError{Pos: ident.Token.Pos, Actual: ident.Token.Type, Msg: msg})
+}
+
+func (r *resolver) resolve(node ast.Node) {
+	switch node := node.(type) {
+	case *ast.LetStatement:
+		r.resolve(node.Value)
+
+		if node.Pattern == nil {
+			r.declare(node.Name, node.IsConst())
+		} else {
+			for _, name := range ast.PatternBindings(node.Pattern) {
+				r.declare(name, node.IsConst())
+			}
+		}
+	case *ast.ReturnStatement:
+		r.resolve(node.ReturnValue)
+	case *ast.ThrowStatement:
+		r.resolve(node.Value)
+	case *ast.ExpressionStatement:
+		r.resolve(node.Expression)
+	case *ast.BlockStatement:
+		for _, stmt := range node.Statements {
+			r.resolve(stmt)
+		}
+	case *ast.WhileStatement:
+		r.resolve(node.Condition)
+		r.push()
+		r.resolve(node.Body)
+		r.pop()
+	case *ast.ForStatement:
+		r.resolve(node.Iterable)
+		r.push()
+		r.declare(node.Variable, false)
+		r.resolve(node.Body)
+		r.pop()
+	case *ast.FunctionLiteral:
+		r.push()
+
+		for i, param := range node.Parameters {
+			if i < len(node.Defaults) && node.Defaults[i] != nil {
+				r.resolve(node.Defaults[i])
+			}
+
+			r.declare(param, false)
+		}
+
+		if node.Rest != nil {
+			r.declare(node.Rest, false)
+		}
+
+		r.resolve(node.Body)
+		r.pop()
+	case *ast.AssignExpression:
+		if ident, ok := node.Target.(*ast.Identifier); ok && r.isConst(ident.Value) {
+			r.addError(ident, fmt.Sprintf("cannot assign to constant %s", ident.Value))
+		}
+
+		r.resolve(node.Target)
+		r.resolve(node.Value)
+	case *ast.PrefixExpression:
+		r.resolve(node.Right)
+	case *ast.InfixExpression:
+		r.resolve(node.Left)
+		r.resolve(node.Right)
+	case *ast.IfExpression:
+		r.resolve(node.Condition)
+		r.resolve(node.Consequence)
+
+		if node.Alternative != nil {
+			r.resolve(node.Alternative)
+		}
+	case *ast.CallExpression:
+		r.resolve(node.Function)
+
+		for _, arg := range node.Arguments {
+			r.resolve(arg)
+		}
+
+		for _, arg := range node.NamedArguments {
+			r.resolve(arg.Value)
+		}
+	case *ast.ArrayLiteral:
+		for _, el := range node.Elements {
+			r.resolve(el)
+		}
+	case *ast.IndexExpression:
+		r.resolve(node.Left)
+		r.resolve(node.Index)
+	case *ast.HashLiteral:
+		for _, pair := range node.Pairs {
+			r.resolve(pair.Key)
+			r.resolve(pair.Value)
+		}
+	case *ast.MatchExpression:
+		r.resolve(node.Subject)
+
+		for _, arm := range node.Arms {
+			r.push()
+
+			for _, name := range ast.PatternBindings(arm.Pattern) {
+				r.declare(name, false)
+			}
+
+			r.resolve(arm.Body)
+			r.pop()
+		}
+	case *ast.TryExpression:
+		r.resolve(node.Block)
+
+		if node.Catch != nil {
+			r.push()
+			r.declare(node.CatchParameter, false)
+			r.resolve(node.Catch)
+			r.pop()
+		}
+
+		if node.Finally != nil {
+			r.resolve(node.Finally)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/henningrck/monkey-interpreter/lexer"
+	"github.com/henningrck/monkey-interpreter/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstStatement(t *testing.T) {
+	l := lexer.New("const PI = 3; const [a, b] = pair;")
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	assert.Equal(t, "const PI = 3;const [a, b] = pair;", program.String())
+}
+
+func TestResolveConstants(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"const PI = 3; PI", []string{}},
+		{"const PI = 3; PI = 4;", []string{"line 1:15: cannot assign to constant PI"}},
+		{"const PI = 3; PI += 1;", []string{"line 1:15: cannot assign to constant PI"}},
+		{"const PI = 3; let PI = 4;", []string{"line 1:19: cannot redeclare constant PI"}},
+		{"const PI = 3; const PI = 4;", []string{"line 1:21: cannot redeclare constant PI"}},
+		{"let x = 1; const x = 2;", []string{"line 1:18: cannot redeclare x as a constant"}},
+		{"let x = 1; let x = 2; x = 3;", []string{}},
+		{"const [a, b] = [1, 2]; b = 3;", []string{"line 1:24: cannot assign to constant b"}},
+		{"const {name} = person; let name = 1;", []string{"line 1:28: cannot redeclare constant name"}},
+		{"const PI = 3; let f = fn() { PI = 4 };", []string{"line 1:30: cannot assign to constant PI"}},
+		{"const PI = 3; let f = fn(PI) { PI = 4 };", []string{}},
+		{"const PI = 3; let f = fn() { let PI = 4; PI = 5 };", []string{}},
+		{"const PI = 3; if (true) { PI = 4 };", []string{"line 1:27: cannot assign to constant PI"}},
+		{"const PI = 3; if (true) { const PI = 4 };", []string{"line 1:33: cannot redeclare constant PI"}},
+		{"const PI = 3; while (true) { const PI = 4; break };", []string{}},
+		{"const PI = 3; for (PI in [1]) { PI = 2 };", []string{}},
+		{"const x = 1; for (i in [1]) { x = i };", []string{"line 1:31: cannot assign to constant x"}},
+		{"const x = 1; match (1) { x => x = 2 };", []string{}},
+		{"const x = 1; match (1) { y => x = 2 };", []string{"line 1:31: cannot assign to constant x"}},
+		{"const e = 1; try { e = 2 } catch (e) { e = 3 };", []string{"line 1:20: cannot assign to constant e"}},
+		{"const arr = [1]; arr[0] = 2;", []string{}},
+		{"let f = fn() { x = 2 }; const x = 1;", []string{}},
+		{"let f = fn(a = PI = 1) { a }; const PI = 3;", []string{}},
+	}
+
+	for _, test := range tests {
+		l := lexer.New(test.input)
+		p := parser.New(l)
+		p.ParseProgram()
+
+		messages := []string{}
+
+		for _, err := range p.Errors() {
+			messages = append(messages, err.Error())
+		}
+
+		assert.Equal(t, test.expected, messages, test.input)
+	}
+}
+
+func TestResolveSkipsProgramsWithSyntaxErrors(t *testing.T) {
+	l := lexer.New("const PI = 3; PI = 4; let = 5;")
+	p := parser.New(l)
+	p.ParseProgram()
+
+	if assert.Len(t, p.Errors(), 1) {
+		assert.Equal(t, "line 1:27: expected next token to be IDENT, got = instead", p.Errors()[0].Error())
+	}
+}
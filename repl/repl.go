@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/henningrck/monkey-interpreter/evaluator"
 	"github.com/henningrck/monkey-interpreter/lexer"
+	"github.com/henningrck/monkey-interpreter/object"
 	"github.com/henningrck/monkey-interpreter/parser"
 )
 
@@ -13,6 +15,8 @@ const PROMPT = ">> "
 
 func Start(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
+	env := object.NewEnvironment()
+	evaluator.Output = out
 
 	for {
 		fmt.Print(PROMPT)
@@ -28,20 +32,34 @@ func Start(in io.Reader, out io.Writer) {
 		program := p.ParseProgram()
 
 		if len(p.Errors()) != 0 {
-			printParserErrors(out, p.Errors())
+			printParserErrors(out, line, p.Errors())
 			continue
 		}
 
-		io.WriteString(out, program.String())
-		io.WriteString(out, "\n")
+		for _, warning := range p.Warnings() {
+			io.WriteString(out, "warning: "+warning.Render(line)+"\n")
+		}
+
+		evaluated := evaluator.Eval(program, env)
+
+		if evaluated != nil {
+			io.WriteString(out, evaluated.Inspect())
+			io.WriteString(out, "\n")
+		}
+
+		if err, ok := evaluated.(*object.Error); ok {
+			for _, frame := range err.Stack {
+				io.WriteString(out, "\tat "+frame.String()+"\n")
+			}
+		}
 	}
 }
 
-func printParserErrors(out io.Writer, errors []string) {
+func printParserErrors(out io.Writer, source string, errors parser.ErrorList) {
 	io.WriteString(out, "Woops! We ran into some monkey business here!\n")
 	io.WriteString(out, "Parser errors:\n")
 
-	for _, msg := range errors {
-		io.WriteString(out, "\t"+msg+"\n")
+	for _, err := range errors {
+		io.WriteString(out, err.Render(source)+"\n")
 	}
 }